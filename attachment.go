@@ -0,0 +1,83 @@
+package client
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// DefaultAttachmentChunkSize is the plaintext chunk size used when none is given (1 MiB)
+const DefaultAttachmentChunkSize = 1024 * 1024
+
+// AttachmentRef references an uploaded attachment from inside encrypted entry details.
+// The server never sees names, types or content hashes of attachments.
+type AttachmentRef struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+
+	// ContentHash is the SHA-256 of the plaintext, verified on download
+	ContentHash []byte `json:"content_hash"`
+}
+
+// validateAttachment checks the attachment fields the client derives nonces and
+// chunk boundaries from, since they come from the server
+func validateAttachment(attachment *openapi.Attachment) error {
+	if len(attachment.NoncePrefix) != streamNoncePrefixSize {
+		return errors.Wrapf(ErrInvalidAttachment, "nonce prefix is %d bytes, expected %d", len(attachment.NoncePrefix), streamNoncePrefixSize)
+	}
+
+	if attachment.ChunkSize < openapi.MinAttachmentChunkSize || attachment.ChunkSize > openapi.MaxAttachmentChunkSize {
+		return errors.Wrapf(ErrInvalidAttachment, "chunk size %d is out of range", attachment.ChunkSize)
+	}
+
+	if attachment.ChunkCount < 0 || uint64(attachment.ChunkCount) > math.MaxUint32 {
+		return errors.Wrapf(ErrInvalidAttachment, "chunk count %d is out of range", attachment.ChunkCount)
+	}
+
+	// Even an empty attachment is stored as one empty final chunk
+	if attachment.Completed && attachment.ChunkCount == 0 {
+		return errors.Wrap(ErrInvalidAttachment, "completed attachment has no chunks")
+	}
+
+	for _, index := range attachment.UploadedChunks {
+		if index < 0 {
+			return errors.Wrapf(ErrInvalidAttachment, "uploaded chunk index %d is negative", index)
+		}
+	}
+
+	return nil
+}
+
+// sealAttachmentChunk encrypts one chunk as part of a STREAM sequence (see
+// sealStreamChunk). The attachment ID is authenticated so chunks cannot be moved
+// between attachments.
+func sealAttachmentChunk(aead cipher.AEAD, attachment *openapi.Attachment, index int, final bool, chunk []byte) ([]byte, error) {
+	return sealStreamChunk(aead, attachment.NoncePrefix, index, final, chunk, []byte(attachment.Id))
+}
+
+// openAttachmentChunk decrypts one chunk sealed by sealAttachmentChunk
+func openAttachmentChunk(aead cipher.AEAD, attachment *openapi.Attachment, index int, final bool, sealed []byte) ([]byte, error) {
+	chunk, err := openStreamChunk(aead, attachment.NoncePrefix, index, final, sealed, []byte(attachment.Id))
+	if err != nil {
+		return nil, errors.Wrapf(ErrAttachmentCorrupted, "chunk %d failed authentication", index)
+	}
+
+	return chunk, nil
+}
+
+// attachmentContentID derives the deduplication ID of a plaintext hash. It is keyed
+// with the diary key, so the server cannot confirm guesses of the content.
+func attachmentContentID(contentHash []byte, diaryKey []byte) []byte {
+	mac := hmac.New(sha256.New, diaryKey)
+	mac.Write([]byte("thingsdiary-attachment-content-id"))
+	mac.Write(contentHash)
+
+	return mac.Sum(nil)
+}
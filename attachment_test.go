@@ -0,0 +1,150 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+func TestAttachmentChunk_SealOpen(t *testing.T) {
+	key, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	aead, err := newGCM(key)
+	require.NoError(t, err)
+
+	attachment := &openapi.Attachment{
+		Id:          "attachment-1",
+		NoncePrefix: []byte{1, 2, 3, 4, 5, 6, 7},
+	}
+
+	first, err := sealAttachmentChunk(aead, attachment, 0, false, []byte("first"))
+	require.NoError(t, err)
+
+	last, err := sealAttachmentChunk(aead, attachment, 1, true, []byte("last"))
+	require.NoError(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		chunk, err := openAttachmentChunk(aead, attachment, 0, false, first)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("first"), chunk)
+
+		chunk, err = openAttachmentChunk(aead, attachment, 1, true, last)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("last"), chunk)
+	})
+
+	t.Run("reordered chunks", func(t *testing.T) {
+		_, err := openAttachmentChunk(aead, attachment, 0, false, last)
+		assert.ErrorIs(t, err, ErrAttachmentCorrupted)
+	})
+
+	t.Run("truncated attachment", func(t *testing.T) {
+		// Dropping the last chunk leaves a chunk that was not sealed as final
+		_, err := openAttachmentChunk(aead, attachment, 0, true, first)
+		assert.ErrorIs(t, err, ErrAttachmentCorrupted)
+	})
+
+	t.Run("chunk from another attachment", func(t *testing.T) {
+		other := &openapi.Attachment{
+			Id:          "attachment-2",
+			NoncePrefix: attachment.NoncePrefix,
+		}
+
+		_, err := openAttachmentChunk(aead, other, 0, false, first)
+		assert.ErrorIs(t, err, ErrAttachmentCorrupted)
+	})
+}
+
+func TestAttachmentContentID(t *testing.T) {
+	hash := bytes.Repeat([]byte{0xab}, 32)
+	firstKey := bytes.Repeat([]byte{1}, 32)
+	secondKey := bytes.Repeat([]byte{2}, 32)
+
+	assert.Equal(t, attachmentContentID(hash, firstKey), attachmentContentID(hash, firstKey))
+	assert.NotEqual(t, attachmentContentID(hash, firstKey), attachmentContentID(hash, secondKey))
+	assert.Len(t, attachmentContentID(hash, firstKey), openapi.AttachmentContentIDSize)
+}
+
+func TestValidateAttachment(t *testing.T) {
+	valid := func() *openapi.Attachment {
+		return &openapi.Attachment{
+			Id:          "attachment-1",
+			ChunkSize:   DefaultAttachmentChunkSize,
+			ChunkCount:  2,
+			Completed:   true,
+			NoncePrefix: make([]byte, streamNoncePrefixSize),
+		}
+	}
+
+	require.NoError(t, validateAttachment(valid()))
+
+	testCases := []struct {
+		name   string
+		modify func(a *openapi.Attachment)
+	}{
+		{name: "short nonce prefix", modify: func(a *openapi.Attachment) { a.NoncePrefix = []byte{1, 2, 3} }},
+		{name: "long nonce prefix", modify: func(a *openapi.Attachment) { a.NoncePrefix = make([]byte, 12) }},
+		{name: "missing nonce prefix", modify: func(a *openapi.Attachment) { a.NoncePrefix = nil }},
+		{name: "zero chunk size", modify: func(a *openapi.Attachment) { a.ChunkSize = 0 }},
+		{name: "negative chunk size", modify: func(a *openapi.Attachment) { a.ChunkSize = -1 }},
+		{name: "huge chunk size", modify: func(a *openapi.Attachment) { a.ChunkSize = openapi.MaxAttachmentChunkSize + 1 }},
+		{name: "negative chunk count", modify: func(a *openapi.Attachment) { a.ChunkCount = -1 }},
+		{name: "completed without chunks", modify: func(a *openapi.Attachment) { a.ChunkCount = 0 }},
+		{name: "negative uploaded chunk", modify: func(a *openapi.Attachment) { a.UploadedChunks = []int{0, -1} }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attachment := valid()
+			tc.modify(attachment)
+
+			assert.ErrorIs(t, validateAttachment(attachment), ErrInvalidAttachment)
+		})
+	}
+}
+
+func TestStreamChunk_InvalidNoncePrefix(t *testing.T) {
+	key, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	aead, err := newGCM(key)
+	require.NoError(t, err)
+
+	// A wrong-length prefix must fail instead of panicking inside GCM
+	_, err = sealStreamChunk(aead, []byte{1, 2, 3}, 0, true, []byte("chunk"), nil)
+	assert.Error(t, err)
+
+	_, err = openStreamChunk(aead, make([]byte, 12), 0, true, []byte("sealed chunk data"), nil)
+	assert.Error(t, err)
+}
+
+func TestGetAttachment_Malformed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openapi.GetAttachmentResponse{
+			Attachment: openapi.Attachment{
+				Id:          "attachment-1",
+				ChunkSize:   0,
+				ChunkCount:  1,
+				Completed:   true,
+				NoncePrefix: []byte{1, 2, 3},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	client.authToken = "token"
+
+	_, err := client.getAttachment(context.Background(), "diary-1", "attachment-1")
+	assert.ErrorIs(t, err, ErrInvalidAttachment)
+}
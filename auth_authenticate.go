@@ -32,6 +32,7 @@ func (c *Client) Authenticate(ctx context.Context, login, password, seedPhrase s
 	// Only set credentials and token after successful authentication
 	c.credentials = credentials
 	c.authToken = verifyResult.Token
+	c.deviceID = ""
 
 	return nil
 }
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// AuthenticateDevice logs in with the key pair of an enrolled device instead of the
+// seed phrase. Requests are then signed with the device signing key and diary keys
+// are opened with the device encryption key.
+func (c *Client) AuthenticateDevice(ctx context.Context, login, password, deviceID string, device *Credentials) error {
+	loginResult, err := c.login(ctx, login, password)
+	if err != nil {
+		return errors.Wrap(err, "login failed")
+	}
+
+	signedNonce := ed25519.Sign(device.SigningPrivateKey, loginResult.Nonce)
+	verifyResult, err := c.deviceLoginVerify(ctx, loginResult.ChallengeId, deviceID, signedNonce)
+	if err != nil {
+		return errors.Wrap(err, "login failed")
+	}
+
+	// Only set credentials and token after successful authentication
+	c.credentials = device
+	c.authToken = verifyResult.Token
+	c.deviceID = deviceID
+
+	return nil
+}
+
+func (c *Client) deviceLoginVerify(ctx context.Context, challengeID, deviceID string, signedNonce []byte) (*openapi.LoginVerifyResponse, error) {
+	body := openapi.DeviceLoginVerifyRequest{
+		ChallengeId: challengeID,
+		DeviceId:    deviceID,
+		SignedNonce: signedNonce,
+	}
+
+	if err := body.Validate(); err != nil {
+		return nil, errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/auth/login/device", c.baseURL)
+	req, err := c.newRequest(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDeviceNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, ErrInvalidChallenge
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("response status code: %s", resp.Status)
+	}
+
+	var r openapi.LoginVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// ChangePassword replaces the password of the authenticated account.
+// The seed phrase and therefore all encryption keys stay the same.
+func (c *Client) ChangePassword(ctx context.Context, oldPassword, newPassword string) error {
+	body := openapi.ChangePasswordRequest{
+		OldPassword: oldPassword,
+		NewPassword: newPassword,
+	}
+
+	if err := body.Validate(); err != nil {
+		return errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/auth/password", c.baseURL)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+
+	if resp.StatusCode == http.StatusBadRequest {
+		var errorResp openapi.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil {
+			if errorResp.ErrorCode == openapi.ResponseErrorCodeInvalidCredentials {
+				return ErrInvalidCredentials
+			}
+		}
+
+		return errors.Errorf("bad request: %s", resp.Status)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("change password failed: %s", resp.Status)
+	}
+
+	return nil
+}
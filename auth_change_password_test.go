@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestAuth_ChangePassword() {
+	t := s.T()
+	ctx := context.Background()
+
+	var login = fmt.Sprintf("test-change-password-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	// Act
+	err = s.client.ChangePassword(ctx, "password-123", "password-456")
+	require.NoError(t, err)
+
+	// Assert: Only the new password works
+	err = NewClient(WithBaseURL("http://localhost:8081/api")).Authenticate(ctx, login, "password-123", s.seedPhrase)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	err = NewClient(WithBaseURL("http://localhost:8081/api")).Authenticate(ctx, login, "password-456", s.seedPhrase)
+	require.NoError(t, err)
+}
+
+func (s *ClientSuite) TestAuth_ChangePassword_WrongOldPassword() {
+	t := s.T()
+	ctx := context.Background()
+
+	var login = fmt.Sprintf("test-change-password-wrong-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	// Act
+	err = s.client.ChangePassword(ctx, "wrong-password", "password-456")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func (s *ClientSuite) TestAuth_ChangePassword_Unauthenticated() {
+	t := s.T()
+	ctx := context.Background()
+
+	err := s.client.ChangePassword(ctx, "password-123", "password-456")
+	require.Error(t, err)
+}
@@ -31,6 +31,7 @@ func (c *Client) Logout(ctx context.Context) error {
 
 	c.authToken = ""
 	c.credentials = nil
+	c.deviceID = ""
 
 	return nil
 }
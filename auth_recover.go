@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// RecoverAccount resets a forgotten password by proving possession of the seed phrase.
+// The server challenge is signed with the signing key derived from the seed phrase,
+// the same way Authenticate does, and the client is authenticated on success.
+func (c *Client) RecoverAccount(ctx context.Context, login, seedPhrase, newPassword string) error {
+	recoveryResult, err := c.recovery(ctx, login)
+	if err != nil {
+		return errors.Wrap(err, "recovery failed")
+	}
+
+	credentials, err := NewCredentials(seedPhrase)
+	if err != nil {
+		return err
+	}
+
+	signedNonce := ed25519.Sign(credentials.SigningPrivateKey, recoveryResult.Nonce)
+	verifyResult, err := c.recoveryVerify(ctx, recoveryResult.ChallengeId, signedNonce, newPassword)
+	if err != nil {
+		return errors.Wrap(err, "recovery failed")
+	}
+
+	// Only set credentials and token after successful recovery
+	c.credentials = credentials
+	c.authToken = verifyResult.Token
+	c.deviceID = ""
+
+	return nil
+}
+
+func (c *Client) recovery(ctx context.Context, login string) (*openapi.RecoveryResponse, error) {
+	body := openapi.RecoveryRequest{
+		Login: login,
+	}
+
+	if err := body.Validate(); err != nil {
+		return nil, errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/auth/recovery", c.baseURL)
+	req, err := c.newRequest(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrAccountNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("response status code: %s", resp.Status)
+	}
+
+	var r openapi.RecoveryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func (c *Client) recoveryVerify(ctx context.Context, challengeID string, signedNonce []byte, newPassword string) (*openapi.RecoveryVerifyResponse, error) {
+	body := openapi.RecoveryVerifyRequest{
+		ChallengeId: challengeID,
+		SignedNonce: signedNonce,
+		NewPassword: newPassword,
+	}
+
+	if err := body.Validate(); err != nil {
+		return nil, errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/auth/recovery/verify", c.baseURL)
+	req, err := c.newRequest(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, ErrInvalidChallenge
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("response status code: %s", resp.Status)
+	}
+
+	var r openapi.RecoveryVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestAuth_RecoverAccount() {
+	t := s.T()
+	ctx := context.Background()
+
+	var login = fmt.Sprintf("test-recover-account-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "forgotten-password", s.seedPhrase)
+	require.NoError(t, err)
+
+	// Act
+	err = s.client.RecoverAccount(ctx, login, s.seedPhrase, "password-456")
+
+	// Assert: Client is authenticated right away
+	require.NoError(t, err)
+	require.NotEmpty(t, s.client.authToken)
+	require.NotNil(t, s.client.credentials)
+
+	// Assert: New password works for a fresh login
+	err = NewClient(WithBaseURL("http://localhost:8081/api")).Authenticate(ctx, login, "password-456", s.seedPhrase)
+	require.NoError(t, err)
+}
+
+func (s *ClientSuite) TestAuth_RecoverAccount_WrongSeedPhrase() {
+	t := s.T()
+	ctx := context.Background()
+
+	var login = fmt.Sprintf("test-recover-account-wrong-seed-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "forgotten-password", s.seedPhrase)
+	require.NoError(t, err)
+
+	// Act
+	err = s.client.RecoverAccount(ctx, login, "not the seed phrase", "password-456")
+
+	// Assert: Password was not reset
+	assert.ErrorIs(t, err, ErrInvalidChallenge)
+	assert.Empty(t, s.client.authToken)
+	assert.Nil(t, s.client.credentials)
+
+	err = NewClient(WithBaseURL("http://localhost:8081/api")).Authenticate(ctx, login, "forgotten-password", s.seedPhrase)
+	require.NoError(t, err)
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// AuthorizeDeviceParams contains the public keys of a device to enroll
+type AuthorizeDeviceParams struct {
+	Name                string
+	SigningPublicKey    []byte
+	EncryptionPublicKey []byte
+}
+
+// AuthorizeDevice enrolls a device from the primary device holding the account keys.
+// The device public keys are signed with the account signing key and every diary
+// key is sealed to the device encryption key. Compare the device fingerprint out
+// of band before authorizing it.
+func (c *Client) AuthorizeDevice(ctx context.Context, params AuthorizeDeviceParams) (*Device, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	message := deviceAuthorizationMessage(params.SigningPublicKey, params.EncryptionPublicKey)
+
+	request := openapi.AuthorizeDeviceRequest{
+		Name:                params.Name,
+		SignaturePublicKey:  params.SigningPublicKey,
+		EncryptionPublicKey: params.EncryptionPublicKey,
+		DeviceKeySignature:  signBytes(message, c.credentials.SigningPrivateKey),
+	}
+
+	// Validate request before sending
+	if err := request.Validate(); err != nil {
+		return nil, errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/devices", c.baseURL)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodPost, url, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+
+	signature := signBytes(requestJSON, c.credentials.SigningPrivateKey)
+	req.Header.Set("X-Signature", base64.StdEncoding.EncodeToString(signature))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	var apiResponse openapi.AuthorizeDeviceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response")
+	}
+
+	device := convertDevice(&apiResponse.Device)
+
+	if err := c.sealDiaryKeysForDevice(ctx, device); err != nil {
+		return nil, errors.Wrap(err, "device enrolled but diary keys were not shared with it")
+	}
+
+	return device, nil
+}
+
+// SyncDeviceKeys seals every diary key to every enrolled device. Call it from the
+// primary device after creating diaries, so other devices can read them.
+func (c *Client) SyncDeviceKeys(ctx context.Context) error {
+	if c.credentials == nil {
+		return ErrUnauthorized
+	}
+
+	devicesData, err := c.getDevices(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get devices")
+	}
+
+	for _, deviceData := range devicesData {
+		if err := c.sealDiaryKeysForDevice(ctx, convertDevice(deviceData)); err != nil {
+			return errors.Wrapf(err, "failed to share diary keys with device %s", deviceData.Id)
+		}
+	}
+
+	return nil
+}
+
+// sealDiaryKeysForDevice stores every key of every diary sealed to the device encryption key
+func (c *Client) sealDiaryKeysForDevice(ctx context.Context, device *Device) error {
+	diariesData, err := c.getDiaries(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get diaries")
+	}
+
+	var envelopes []openapi.DeviceDiaryKeyEnvelope
+	for _, diaryData := range diariesData {
+		keyring, err := c.getDiaryKeyring(ctx, diaryData.Id)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get keys of diary %s", diaryData.Id)
+		}
+
+		for keyID, diaryKey := range keyring.keys {
+			encryptedDiaryKey, err := encryptWithPublicKey(diaryKey, device.EncryptionPublicKey)
+			if err != nil {
+				return errors.Wrap(err, "failed to encrypt diary key")
+			}
+
+			envelopes = append(envelopes, openapi.DeviceDiaryKeyEnvelope{
+				DiaryId:    diaryData.Id,
+				DiaryKeyId: keyID,
+				Value:      encryptedDiaryKey,
+			})
+		}
+	}
+
+	if len(envelopes) == 0 {
+		return nil
+	}
+
+	request := openapi.PutDeviceKeysRequest{
+		EncryptedDiaryKeys: envelopes,
+	}
+
+	// Validate request before sending
+	if err := request.Validate(); err != nil {
+		return errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/devices/%s/keys", c.baseURL, device.ID)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodPut, url, request)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request")
+	}
+
+	signature := signBytes(requestJSON, c.credentials.SigningPrivateKey)
+	req.Header.Set("X-Signature", base64.StdEncoding.EncodeToString(signature))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrDeviceNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	return nil
+}
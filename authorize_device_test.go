@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestDevice_AuthorizeDevice() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: Primary device with a diary
+	var login = fmt.Sprintf("test-authorize-device-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Written on the primary device"})
+	require.NoError(t, err)
+
+	deviceCredentials, err := NewDeviceCredentials()
+	require.NoError(t, err)
+
+	// Act
+	device, err := s.client.AuthorizeDevice(ctx, AuthorizeDeviceParams{
+		Name:                "Laptop",
+		SigningPublicKey:    deviceCredentials.SigningPublicKey,
+		EncryptionPublicKey: deviceCredentials.EncryptionPublicKey,
+	})
+
+	// Assert: Device was enrolled
+	require.NoError(t, err)
+	require.NotNil(t, device)
+	assert.NotEmpty(t, device.ID)
+	assert.Equal(t, "Laptop", device.Name)
+	assert.Equal(t, deviceCredentials.SigningPublicKey, device.SigningPublicKey)
+
+	// Assert: Device authenticates with its own keys and reads and writes entries
+	deviceClient := NewClient(WithBaseURL("http://localhost:8081/api"))
+	err = deviceClient.AuthenticateDevice(ctx, login, "password-123", device.ID, deviceCredentials)
+	require.NoError(t, err)
+
+	gotEntry, err := deviceClient.GetEntryByID(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Written on the primary device", gotEntry.Content)
+
+	_, err = deviceClient.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Written on the laptop"})
+	require.NoError(t, err)
+}
+
+func (s *ClientSuite) TestDevice_SyncDeviceKeys() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: Device enrolled before the diary exists
+	var login = fmt.Sprintf("test-sync-device-keys-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	deviceCredentials, err := NewDeviceCredentials()
+	require.NoError(t, err)
+
+	device, err := s.client.AuthorizeDevice(ctx, AuthorizeDeviceParams{
+		Name:                "Phone",
+		SigningPublicKey:    deviceCredentials.SigningPublicKey,
+		EncryptionPublicKey: deviceCredentials.EncryptionPublicKey,
+	})
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Created later"})
+	require.NoError(t, err)
+
+	// Act
+	err = s.client.SyncDeviceKeys(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	deviceClient := NewClient(WithBaseURL("http://localhost:8081/api"))
+	err = deviceClient.AuthenticateDevice(ctx, login, "password-123", device.ID, deviceCredentials)
+	require.NoError(t, err)
+
+	gotDiary, err := deviceClient.GetDiaryByID(ctx, diary.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Created later", gotDiary.Title)
+}
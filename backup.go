@@ -0,0 +1,530 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// BackupFormatVersion is the archive format version written by Backup
+const BackupFormatVersion = 1
+
+// Names of the archive members describing a backup
+const (
+	backupManifestName  = "manifest.json"
+	backupSignatureName = "manifest.sig"
+)
+
+// Limits of the archive members and of the whole archive accepted on restore
+const (
+	maxBackupManifestSize = 64 << 20
+	maxBackupFileSize     = 1 << 30
+	maxBackupSize         = 16 << 30
+)
+
+// BackupManifest lists the files of a backup archive with their hashes. It is
+// signed with the account signing key, so the archive cannot be altered unnoticed.
+type BackupManifest struct {
+	FormatVersion    int          `json:"format_version"`
+	CreatedAt        time.Time    `json:"created_at"`
+	SigningPublicKey []byte       `json:"signing_public_key"`
+	DiaryIDs         []string     `json:"diary_ids"`
+	Files            []BackupFile `json:"files"`
+}
+
+// BackupFile is a file of a backup archive
+type BackupFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 []byte `json:"sha256"`
+}
+
+// backupDiary is the content of a diary in a backup archive, stored exactly as the
+// server returned it
+type backupDiary struct {
+	Diary     *openapi.Diary
+	Keys      []openapi.DiaryEncryptionKey
+	Entries   []*openapi.Entry
+	Topics    []*openapi.Topic
+	Templates []*openapi.Template
+}
+
+// Names of the files written for every diary, in archive order
+const (
+	backupDiaryFile     = "diary.json"
+	backupKeysFile      = "keys.json"
+	backupEntriesFile   = "entries.json"
+	backupTopicsFile    = "topics.json"
+	backupTemplatesFile = "templates.json"
+)
+
+var backupDiaryFiles = []string{backupDiaryFile, backupKeysFile, backupEntriesFile, backupTopicsFile, backupTemplatesFile}
+
+// files maps the names of the diary files to the fields holding their content
+func (d *backupDiary) files() map[string]any {
+	return map[string]any{
+		backupDiaryFile:     &d.Diary,
+		backupKeysFile:      &d.Keys,
+		backupEntriesFile:   &d.Entries,
+		backupTopicsFile:    &d.Topics,
+		backupTemplatesFile: &d.Templates,
+	}
+}
+
+// Backup writes every diary of the account with its keys, entries, topics and
+// templates into w as a tar archive. Content stays encrypted as stored on the
+// server, including deleted entities. Attachment content is not included.
+//
+// The signed manifest leads the archive, so the files are spooled to a
+// temporary file while their hashes are computed; entities are fetched and
+// written page by page instead of being held in memory.
+func (c *Client) Backup(ctx context.Context, w io.Writer) (*BackupManifest, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	diaries, err := c.getDiaries(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get diaries")
+	}
+
+	sort.Slice(diaries, func(i, j int) bool {
+		return diaries[i].Id < diaries[j].Id
+	})
+
+	archive, err := newBackupWriter(time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer archive.discard()
+
+	for _, diary := range diaries {
+		if err := c.backupDiary(ctx, archive, diary); err != nil {
+			return nil, err
+		}
+	}
+
+	return archive.finish(w, c.credentials)
+}
+
+func (c *Client) backupDiary(ctx context.Context, archive *backupWriter, diary *openapi.Diary) error {
+	keys, err := c.getDiaryKeys(ctx, diary.Id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get keys of diary %s", diary.Id)
+	}
+
+	return archive.addDiary(diary.Id, func(name string, w io.Writer) error {
+		switch name {
+		case backupDiaryFile:
+			return json.NewEncoder(w).Encode(diary)
+		case backupKeysFile:
+			return json.NewEncoder(w).Encode(keys)
+		case backupEntriesFile:
+			params := openapi.GetEntriesParams{}
+			return writeBackupPages(w, func(token *string) ([]*openapi.Entry, mo.Option[string], error) {
+				params.NextPageToken = token
+				page, err := c.getEntriesPage(ctx, diary.Id, params)
+				if err != nil {
+					return nil, mo.None[string](), errors.Wrapf(err, "failed to get entries of diary %s", diary.Id)
+				}
+				return page.Entries, page.NextPageToken, nil
+			})
+		case backupTopicsFile:
+			params := openapi.GetTopicsParams{}
+			return writeBackupPages(w, func(token *string) ([]*openapi.Topic, mo.Option[string], error) {
+				params.NextPageToken = token
+				page, err := c.getTopicsPage(ctx, diary.Id, params)
+				if err != nil {
+					return nil, mo.None[string](), errors.Wrapf(err, "failed to get topics of diary %s", diary.Id)
+				}
+				return page.Topics, page.NextPageToken, nil
+			})
+		case backupTemplatesFile:
+			params := openapi.GetTemplatesParams{}
+			return writeBackupPages(w, func(token *string) ([]*openapi.Template, mo.Option[string], error) {
+				params.NextPageToken = token
+				page, err := c.getTemplatesPage(ctx, diary.Id, params)
+				if err != nil {
+					return nil, mo.None[string](), errors.Wrapf(err, "failed to get templates of diary %s", diary.Id)
+				}
+				return page.Templates, page.NextPageToken, nil
+			})
+		}
+
+		return errors.Errorf("unknown diary file %s", name)
+	})
+}
+
+// writeBackupPages writes the items of every page returned by fetch as one JSON
+// array. fetch gets the token of the page to load, nil for the first page.
+func writeBackupPages[T any](w io.Writer, fetch func(token *string) ([]T, mo.Option[string], error)) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	var token *string
+	seen := map[string]bool{}
+	written := 0
+	for {
+		items, next, err := fetch(token)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			data, err := json.Marshal(item)
+			if err != nil {
+				return errors.Wrap(err, "failed to marshal item")
+			}
+
+			if written > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			written++
+		}
+
+		if token, err = nextPageToken(next, seen); err != nil {
+			return err
+		}
+		if token == nil {
+			break
+		}
+	}
+
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+// backupWriter spools the files of a backup archive to a temporary file while
+// recording them in the manifest, then writes the archive with the manifest first.
+// The spool file is encrypted with a key that never leaves memory, so nothing of
+// the backup is left readable on disk.
+type backupWriter struct {
+	spool    *os.File
+	key      []byte
+	enc      io.WriteCloser
+	manifest BackupManifest
+}
+
+func newBackupWriter(createdAt time.Time) (*backupWriter, error) {
+	spool, err := os.CreateTemp("", "thingsdiary-backup-*")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create spool file")
+	}
+
+	key, err := generateSymmetricKey()
+	if err != nil {
+		spool.Close()
+		os.Remove(spool.Name())
+		return nil, err
+	}
+
+	enc, err := NewStreamWriter(spool, key, nil)
+	if err != nil {
+		spool.Close()
+		os.Remove(spool.Name())
+		return nil, err
+	}
+
+	return &backupWriter{
+		spool: spool,
+		key:   key,
+		enc:   enc,
+		manifest: BackupManifest{
+			FormatVersion: BackupFormatVersion,
+			CreatedAt:     createdAt,
+		},
+	}, nil
+}
+
+// addDiary spools the files of a diary, calling write for each name of backupDiaryFiles
+func (b *backupWriter) addDiary(diaryID string, write func(name string, w io.Writer) error) error {
+	dir := path.Join("diaries", diaryID)
+	for _, name := range backupDiaryFiles {
+		if err := b.addFile(path.Join(dir, name), func(w io.Writer) error { return write(name, w) }); err != nil {
+			return err
+		}
+	}
+
+	b.manifest.DiaryIDs = append(b.manifest.DiaryIDs, diaryID)
+
+	return nil
+}
+
+func (b *backupWriter) addFile(name string, write func(w io.Writer) error) error {
+	hash := sha256.New()
+	size := &byteCounter{}
+	if err := write(io.MultiWriter(b.enc, hash, size)); err != nil {
+		return errors.Wrapf(err, "failed to write %s", name)
+	}
+
+	if size.n > maxBackupFileSize {
+		return errors.Errorf("%s is too large", name)
+	}
+
+	b.manifest.Files = append(b.manifest.Files, BackupFile{Name: name, Size: size.n, SHA256: hash.Sum(nil)})
+
+	return nil
+}
+
+// finish signs the manifest and writes the archive into w
+func (b *backupWriter) finish(w io.Writer, credentials *Credentials) (*BackupManifest, error) {
+	if err := b.enc.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to write spool file")
+	}
+
+	if _, err := b.spool.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "failed to read spool file")
+	}
+
+	spool, err := NewStreamReader(b.spool, b.key, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read spool file")
+	}
+
+	b.manifest.SigningPublicKey = credentials.SigningPublicKey
+
+	manifestJSON, err := json.Marshal(b.manifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal manifest")
+	}
+
+	tw := tar.NewWriter(w)
+	if err := b.writeHeader(tw, backupManifestName, int64(len(manifestJSON))); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write(manifestJSON); err != nil {
+		return nil, errors.Wrapf(err, "failed to write %s", backupManifestName)
+	}
+
+	signature := signBytes(manifestJSON, credentials.SigningPrivateKey)
+	if err := b.writeHeader(tw, backupSignatureName, int64(len(signature))); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write(signature); err != nil {
+		return nil, errors.Wrapf(err, "failed to write %s", backupSignatureName)
+	}
+
+	for _, file := range b.manifest.Files {
+		if err := b.writeHeader(tw, file.Name, file.Size); err != nil {
+			return nil, err
+		}
+
+		if _, err := io.CopyN(tw, spool, file.Size); err != nil {
+			return nil, errors.Wrapf(err, "failed to write %s", file.Name)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to finish archive")
+	}
+
+	return &b.manifest, nil
+}
+
+func (b *backupWriter) writeHeader(tw *tar.Writer, name string, size int64) error {
+	header := tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    size,
+		ModTime: b.manifest.CreatedAt,
+		Format:  tar.FormatPAX,
+	}
+
+	if err := tw.WriteHeader(&header); err != nil {
+		return errors.Wrapf(err, "failed to write %s", name)
+	}
+
+	return nil
+}
+
+// discard removes the spool file
+func (b *backupWriter) discard() {
+	b.spool.Close()
+	os.Remove(b.spool.Name())
+}
+
+// byteCounter counts the bytes written to it
+type byteCounter struct {
+	n int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+// backupReader reads a backup archive diary by diary. The signed manifest leading
+// the archive is verified when it is opened, and every file is checked against it
+// as it is read, so only listed files in the listed order are accepted.
+type backupReader struct {
+	tr       *tar.Reader
+	manifest BackupManifest
+	next     int
+}
+
+// openBackup reads the manifest of a backup archive and verifies its signature
+// with trustedKey
+func openBackup(r io.Reader, trustedKey ed25519.PublicKey) (*backupReader, error) {
+	tr := tar.NewReader(r)
+
+	manifestJSON, err := readBackupMember(tr, backupManifestName, maxBackupManifestSize)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := readBackupMember(tr, backupSignatureName, ed25519.SignatureSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(trustedKey) != ed25519.PublicKeySize || !ed25519.Verify(trustedKey, manifestJSON, signature) {
+		return nil, errors.Wrap(ErrInvalidBackup, "manifest signature does not match")
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return nil, errors.Wrap(ErrInvalidBackup, "manifest cannot be parsed")
+	}
+
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > BackupFormatVersion {
+		return nil, errors.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+	}
+
+	if len(manifest.Files) != len(manifest.DiaryIDs)*len(backupDiaryFiles) {
+		return nil, errors.Wrap(ErrInvalidBackup, "manifest does not list the files of every diary")
+	}
+
+	var total int64
+	for i, file := range manifest.Files {
+		diaryID, name := manifest.DiaryIDs[i/len(backupDiaryFiles)], backupDiaryFiles[i%len(backupDiaryFiles)]
+		if file.Name != path.Join("diaries", diaryID, name) {
+			return nil, errors.Wrapf(ErrInvalidBackup, "manifest lists unexpected file %s", file.Name)
+		}
+
+		if file.Size < 0 || file.Size > maxBackupFileSize {
+			return nil, errors.Wrapf(ErrInvalidBackup, "%s is too large", file.Name)
+		}
+
+		if total += file.Size; total > maxBackupSize {
+			return nil, errors.Wrap(ErrInvalidBackup, "backup is too large")
+		}
+	}
+
+	return &backupReader{tr: tr, manifest: manifest}, nil
+}
+
+// readBackupMember reads the next archive member, which must be name and at most limit bytes
+func readBackupMember(tr *tar.Reader, name string, limit int64) ([]byte, error) {
+	header, err := tr.Next()
+	if err == io.EOF {
+		return nil, errors.Wrapf(ErrInvalidBackup, "%s is missing", name)
+	}
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidBackup, err.Error())
+	}
+
+	if header.Name != name || header.Typeflag != tar.TypeReg {
+		return nil, errors.Wrapf(ErrInvalidBackup, "expected %s, found %s", name, header.Name)
+	}
+
+	if header.Size > limit {
+		return nil, errors.Wrapf(ErrInvalidBackup, "%s is too large", name)
+	}
+
+	data, err := io.ReadAll(tr)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidBackup, "failed to read %s: %s", name, err)
+	}
+
+	return data, nil
+}
+
+// nextDiary reads and verifies the files of the next diary. It returns io.EOF
+// after the last diary once the archive is known to hold nothing else.
+func (b *backupReader) nextDiary() (*backupDiary, error) {
+	if b.next == len(b.manifest.DiaryIDs) {
+		if header, err := b.tr.Next(); err != io.EOF {
+			if err != nil {
+				return nil, errors.Wrap(ErrInvalidBackup, err.Error())
+			}
+			return nil, errors.Wrapf(ErrInvalidBackup, "%s is not listed in the manifest", header.Name)
+		}
+
+		return nil, io.EOF
+	}
+
+	diaryID := b.manifest.DiaryIDs[b.next]
+	files := b.manifest.Files[b.next*len(backupDiaryFiles):][:len(backupDiaryFiles)]
+	b.next++
+
+	var content backupDiary
+	values := content.files()
+	for i, name := range backupDiaryFiles {
+		if err := b.decodeFile(files[i], values[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if content.Diary == nil {
+		return nil, errors.Wrapf(ErrInvalidBackup, "diary %s is empty", diaryID)
+	}
+
+	return &content, nil
+}
+
+// decodeFile reads the next archive member, which must be file, decoding it into
+// value while hashing it. value must not be used when an error is returned.
+func (b *backupReader) decodeFile(file BackupFile, value any) error {
+	header, err := b.tr.Next()
+	if err == io.EOF {
+		return errors.Wrapf(ErrInvalidBackup, "%s is missing", file.Name)
+	}
+	if err != nil {
+		return errors.Wrap(ErrInvalidBackup, err.Error())
+	}
+
+	if header.Name != file.Name || header.Typeflag != tar.TypeReg {
+		return errors.Wrapf(ErrInvalidBackup, "expected %s, found %s", file.Name, header.Name)
+	}
+
+	if header.Size != file.Size {
+		return errors.Wrapf(ErrInvalidBackup, "%s does not match the manifest", file.Name)
+	}
+
+	hash := sha256.New()
+	decodeErr := json.NewDecoder(io.TeeReader(b.tr, hash)).Decode(value)
+
+	if _, err := io.Copy(hash, b.tr); err != nil {
+		return errors.Wrapf(ErrInvalidBackup, "failed to read %s: %s", file.Name, err)
+	}
+
+	if !bytes.Equal(hash.Sum(nil), file.SHA256) {
+		return errors.Wrapf(ErrInvalidBackup, "%s does not match the manifest", file.Name)
+	}
+
+	if decodeErr != nil {
+		return errors.Wrapf(ErrInvalidBackup, "%s cannot be parsed", file.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,386 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+func writeTestBackup(t *testing.T, credentials *Credentials) []byte {
+	t.Helper()
+
+	archive, err := newBackupWriter(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+	defer archive.discard()
+
+	content := backupDiary{
+		Diary:   &openapi.Diary{Id: "diary-1", Version: 7},
+		Entries: []*openapi.Entry{{Id: "entry-1", Version: 3}},
+	}
+	values := content.files()
+	require.NoError(t, archive.addDiary("diary-1", func(name string, w io.Writer) error {
+		return json.NewEncoder(w).Encode(values[name])
+	}))
+
+	var buf bytes.Buffer
+	_, err = archive.finish(&buf, credentials)
+	require.NoError(t, err)
+
+	return buf.Bytes()
+}
+
+// readTestBackup reads every diary of an archive
+func readTestBackup(archive []byte, trustedKey ed25519.PublicKey) (*BackupManifest, []*backupDiary, error) {
+	reader, err := openBackup(bytes.NewReader(archive), trustedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var diaries []*backupDiary
+	for {
+		content, err := reader.nextDiary()
+		if err == io.EOF {
+			return &reader.manifest, diaries, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		diaries = append(diaries, content)
+	}
+}
+
+// signedTestManifest writes an archive holding only a signed manifest
+func signedTestManifest(t *testing.T, credentials *Credentials, manifest BackupManifest) []byte {
+	t.Helper()
+
+	manifestJSON, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{
+		{backupManifestName, manifestJSON},
+		{backupSignatureName, signBytes(manifestJSON, credentials.SigningPrivateKey)},
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: file.name, Mode: 0o600, Size: int64(len(file.data))}))
+		_, err := tw.Write(file.data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	return buf.Bytes()
+}
+
+// rewriteBackup copies an archive, passing every file through change. Files
+// returned by change replace the original one in the given order.
+func rewriteBackup(t *testing.T, archive []byte, change func(name string, data []byte) []backupMember) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tr, tw := tar.NewReader(bytes.NewReader(archive)), tar.NewWriter(&buf)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+
+		for _, member := range change(header.Name, data) {
+			copied := *header
+			copied.Name, copied.Size = member.name, int64(len(member.data))
+			require.NoError(t, tw.WriteHeader(&copied))
+			_, err = tw.Write(member.data)
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+
+	return buf.Bytes()
+}
+
+type backupMember struct {
+	name string
+	data []byte
+}
+
+func TestBackup_RoundTrip(t *testing.T) {
+	credentials, err := NewCredentials("backup test seed phrase")
+	require.NoError(t, err)
+
+	archive := writeTestBackup(t, credentials)
+
+	manifest, diaries, err := readTestBackup(archive, credentials.SigningPublicKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, BackupFormatVersion, manifest.FormatVersion)
+	assert.Equal(t, []string{"diary-1"}, manifest.DiaryIDs)
+	assert.Len(t, manifest.Files, 5)
+
+	tr := tar.NewReader(bytes.NewReader(archive))
+	header, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, backupManifestName, header.Name)
+
+	require.Len(t, diaries, 1)
+	assert.Equal(t, "diary-1", diaries[0].Diary.Id)
+	assert.Equal(t, uint64(7), diaries[0].Diary.Version)
+	require.Len(t, diaries[0].Entries, 1)
+	assert.Equal(t, "entry-1", diaries[0].Entries[0].Id)
+}
+
+func TestBackup_DetectsTampering(t *testing.T) {
+	credentials, err := NewCredentials("backup test seed phrase")
+	require.NoError(t, err)
+
+	other, err := NewCredentials("another seed phrase")
+	require.NoError(t, err)
+
+	archive := writeTestBackup(t, credentials)
+
+	tests := []struct {
+		name    string
+		archive []byte
+		key     ed25519.PublicKey
+	}{
+		{
+			name:    "untrusted key",
+			archive: archive,
+			key:     other.SigningPublicKey,
+		},
+		{
+			name: "modified file",
+			archive: rewriteBackup(t, archive, func(name string, data []byte) []backupMember {
+				if name == "diaries/diary-1/entries.json" {
+					data = bytes.Replace(data, []byte("entry-1"), []byte("entry-2"), 1)
+				}
+				return []backupMember{{name, data}}
+			}),
+			key: credentials.SigningPublicKey,
+		},
+		{
+			name: "missing file",
+			archive: rewriteBackup(t, archive, func(name string, data []byte) []backupMember {
+				if name == "diaries/diary-1/topics.json" {
+					return nil
+				}
+				return []backupMember{{name, data}}
+			}),
+			key: credentials.SigningPublicKey,
+		},
+		{
+			name: "modified manifest",
+			archive: rewriteBackup(t, archive, func(name string, data []byte) []backupMember {
+				if name == backupManifestName {
+					data = bytes.Replace(data, []byte("diary-1"), []byte("diary-2"), 1)
+				}
+				return []backupMember{{name, data}}
+			}),
+			key: credentials.SigningPublicKey,
+		},
+		{
+			name: "manifest not first",
+			archive: rewriteBackup(t, archive, func(name string, data []byte) []backupMember {
+				if name == backupManifestName {
+					return nil
+				}
+				if name == backupSignatureName {
+					return []backupMember{{name, data}, {backupManifestName, data}}
+				}
+				return []backupMember{{name, data}}
+			}),
+			key: credentials.SigningPublicKey,
+		},
+		{
+			name: "reordered files",
+			archive: rewriteBackup(t, archive, func(name string, data []byte) []backupMember {
+				if name == "diaries/diary-1/keys.json" {
+					return nil
+				}
+				if name == "diaries/diary-1/entries.json" {
+					return []backupMember{{name, data}, {"diaries/diary-1/keys.json", []byte("null\n")}}
+				}
+				return []backupMember{{name, data}}
+			}),
+			key: credentials.SigningPublicKey,
+		},
+		{
+			name: "unlisted file between listed ones",
+			archive: rewriteBackup(t, archive, func(name string, data []byte) []backupMember {
+				if name == "diaries/diary-1/topics.json" {
+					return []backupMember{{"diaries/diary-1/extra.json", []byte("[]")}, {name, data}}
+				}
+				return []backupMember{{name, data}}
+			}),
+			key: credentials.SigningPublicKey,
+		},
+		{
+			name: "unlisted file at the end",
+			archive: rewriteBackup(t, archive, func(name string, data []byte) []backupMember {
+				if name == "diaries/diary-1/templates.json" {
+					return []backupMember{{name, data}, {"extra.json", []byte("[]")}}
+				}
+				return []backupMember{{name, data}}
+			}),
+			key: credentials.SigningPublicKey,
+		},
+		{
+			name: "oversized backup",
+			archive: signedTestManifest(t, credentials, BackupManifest{
+				FormatVersion: BackupFormatVersion,
+				DiaryIDs:      []string{"diary-1", "diary-2", "diary-3", "diary-4"},
+				Files: func() []BackupFile {
+					var files []BackupFile
+					for _, diaryID := range []string{"diary-1", "diary-2", "diary-3", "diary-4"} {
+						for _, name := range backupDiaryFiles {
+							files = append(files, BackupFile{Name: "diaries/" + diaryID + "/" + name, Size: maxBackupFileSize})
+						}
+					}
+					return files
+				}(),
+			}),
+			key: credentials.SigningPublicKey,
+		},
+		{
+			name: "oversized file",
+			archive: signedTestManifest(t, credentials, BackupManifest{
+				FormatVersion: BackupFormatVersion,
+				DiaryIDs:      []string{"diary-1"},
+				Files: []BackupFile{
+					{Name: "diaries/diary-1/diary.json", Size: maxBackupFileSize + 1},
+					{Name: "diaries/diary-1/keys.json"},
+					{Name: "diaries/diary-1/entries.json"},
+					{Name: "diaries/diary-1/topics.json"},
+					{Name: "diaries/diary-1/templates.json"},
+				},
+			}),
+			key: credentials.SigningPublicKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := readTestBackup(tt.archive, tt.key)
+			assert.ErrorIs(t, err, ErrInvalidBackup)
+		})
+	}
+}
+
+func TestBackup_WritesEveryPage(t *testing.T) {
+	credentials, err := NewCredentials("backup test seed phrase")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var response any
+		switch r.URL.Path {
+		case "/v1/diaries":
+			response = openapi.GetDiariesResponse{Diaries: []*openapi.Diary{{Id: "diary-1", Version: 1}}}
+		case "/v1/diaries/diary-1/keys":
+			response = openapi.GetDiaryKeysResponse{Keys: []openapi.DiaryEncryptionKey{}}
+		case "/v1/diaries/diary-1/entries":
+			if r.URL.Query().Get("next_page_token") == "" {
+				response = openapi.GetEntriesResponse{
+					Entries:       []*openapi.Entry{{Id: "entry-1"}, {Id: "entry-2"}},
+					NextPageToken: mo.Some("page-2"),
+				}
+			} else {
+				response = openapi.GetEntriesResponse{Entries: []*openapi.Entry{{Id: "entry-3"}}}
+			}
+		case "/v1/diaries/diary-1/topics":
+			response = openapi.GetTopicsResponse{}
+		case "/v1/diaries/diary-1/templates":
+			response = openapi.GetTemplatesResponse{}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	client.authToken = "token"
+	client.credentials = credentials
+
+	var archive bytes.Buffer
+	_, err = client.Backup(context.Background(), &archive)
+	require.NoError(t, err)
+
+	_, diaries, err := readTestBackup(archive.Bytes(), credentials.SigningPublicKey)
+	require.NoError(t, err)
+
+	require.Len(t, diaries, 1)
+	require.Len(t, diaries[0].Entries, 3)
+	assert.Equal(t, "entry-3", diaries[0].Entries[2].Id)
+	assert.Empty(t, diaries[0].Topics)
+}
+
+func (s *ClientSuite) TestBackup_Restore() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-backup-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	parent, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Work"})
+	require.NoError(t, err)
+
+	child, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Meetings", ParentID: mo.Some(parent.ID)})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Standup notes", TopicID: mo.Some(child.ID)})
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	_, err = s.client.Backup(ctx, &archive)
+	require.NoError(t, err)
+
+	// Act
+	result, err := s.client.Restore(ctx, &archive, RestoreOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Diaries, 1)
+	assert.Equal(t, "Personal Diary", result.Diaries[0].Title)
+	assert.NotEqual(t, diary.ID, result.Diaries[0].ID)
+
+	restored, err := s.client.GetEntryByID(ctx, result.Diaries[0].ID, result.IDs[entry.ID])
+	require.NoError(t, err)
+	assert.Equal(t, "Standup notes", restored.Content)
+	assert.Equal(t, result.IDs[child.ID], restored.TopicID.OrEmpty())
+
+	restoredChild, err := s.client.GetTopicByID(ctx, result.Diaries[0].ID, result.IDs[child.ID])
+	require.NoError(t, err)
+	assert.Equal(t, result.IDs[parent.ID], restoredChild.ParentID.OrEmpty())
+}
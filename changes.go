@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+)
+
+// ChangeKind tells how an entity changed
+type ChangeKind int
+
+const (
+	ChangeCreated ChangeKind = iota
+	ChangeUpdated
+	ChangeDeleted
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeCreated:
+		return "created"
+	case ChangeUpdated:
+		return "updated"
+	case ChangeDeleted:
+		return "deleted"
+	}
+
+	return "unknown"
+}
+
+// Change is a change to one entry, topic or template; exactly one of them is set
+type Change struct {
+	Kind     ChangeKind
+	Version  uint64
+	Entry    *Entry
+	Topic    *Topic
+	Template *Template
+}
+
+// ChangeSet holds the changes of a diary since a version, ordered by version
+type ChangeSet struct {
+	Changes []Change
+
+	// Version is the highest version seen; pass it to the next Changes call
+	Version uint64
+}
+
+// Changes returns the entries, topics and templates of a diary changed after
+// sinceVersion; zero returns everything. Only changed entities are decrypted.
+// Changes queued by OfflineClient get a new version when they are sent, so they
+// are reported after the changes made before they reached the server.
+func (c *Client) Changes(ctx context.Context, diaryID string, sinceVersion uint64) (*ChangeSet, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	// Get encryption keys
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	entriesData, err := c.getEntriesSince(ctx, diaryID, sinceVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get entries")
+	}
+
+	topicsData, err := c.getTopicsSince(ctx, diaryID, sinceVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get topics")
+	}
+
+	templatesData, err := c.getTemplatesSince(ctx, diaryID, sinceVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get templates")
+	}
+
+	changeSet := ChangeSet{Version: sinceVersion}
+	add := func(change Change, createdAt, updatedAt time.Time, deletedAt mo.Option[time.Time]) {
+		change.Kind = changeKind(createdAt, updatedAt, deletedAt)
+		changeSet.Changes = append(changeSet.Changes, change)
+		changeSet.Version = max(changeSet.Version, change.Version)
+	}
+
+	for _, entryData := range entriesData {
+		diaryKey, err := keyring.get(entryData.Encryption.DiaryKeyId)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := c.decryptEntry(entryData, diaryKey)
+		if err != nil {
+			return nil, err
+		}
+
+		add(Change{Version: entry.Version, Entry: entry}, entry.CreatedAt, entry.UpdatedAt, entry.DeletedAt)
+	}
+
+	for _, topicData := range topicsData {
+		diaryKey, err := keyring.get(topicData.Encryption.DiaryKeyId)
+		if err != nil {
+			return nil, err
+		}
+
+		topic, err := c.decryptTopic(topicData, diaryKey)
+		if err != nil {
+			return nil, err
+		}
+
+		add(Change{Version: topic.Version, Topic: topic}, topic.CreatedAt, topic.UpdatedAt, topic.DeletedAt)
+	}
+
+	for _, templateData := range templatesData {
+		diaryKey, err := keyring.get(templateData.Encryption.DiaryKeyId)
+		if err != nil {
+			return nil, err
+		}
+
+		template, err := c.decryptTemplate(templateData, diaryKey)
+		if err != nil {
+			return nil, err
+		}
+
+		add(Change{Version: template.Version, Template: template}, template.CreatedAt, template.UpdatedAt, template.DeletedAt)
+	}
+
+	sortChanges(changeSet.Changes)
+
+	return &changeSet, nil
+}
+
+// changeKind derives the kind of a change from the timestamps of the entity
+func changeKind(createdAt, updatedAt time.Time, deletedAt mo.Option[time.Time]) ChangeKind {
+	switch {
+	case deletedAt.IsPresent():
+		return ChangeDeleted
+	case createdAt.Equal(updatedAt):
+		return ChangeCreated
+	default:
+		return ChangeUpdated
+	}
+}
+
+// sortChanges orders changes by version, then by entity ID
+func sortChanges(changes []Change) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Version != changes[j].Version {
+			return changes[i].Version < changes[j].Version
+		}
+
+		return changes[i].entityID() < changes[j].entityID()
+	})
+}
+
+func (c Change) entityID() string {
+	switch {
+	case c.Entry != nil:
+		return c.Entry.ID
+	case c.Topic != nil:
+		return c.Topic.ID
+	case c.Template != nil:
+		return c.Template.ID
+	}
+
+	return ""
+}
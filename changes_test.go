@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+func TestChangeKind(t *testing.T) {
+	created := time.Now()
+	updated := created.Add(time.Minute)
+
+	assert.Equal(t, ChangeCreated, changeKind(created, created, mo.None[time.Time]()))
+	assert.Equal(t, ChangeUpdated, changeKind(created, updated, mo.None[time.Time]()))
+	assert.Equal(t, ChangeDeleted, changeKind(created, updated, mo.Some(updated)))
+}
+
+func TestGetEntriesSince_FiltersByVersion(t *testing.T) {
+	var sinceVersion string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sinceVersion = r.URL.Query().Get("since_version")
+
+		// Ignores since_version, like servers without version filtering
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openapi.GetEntriesResponse{
+			Entries: []*openapi.Entry{
+				{Id: "entry-new", Version: 20},
+				{Id: "entry-old", Version: 5},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	client.authToken = "token"
+
+	entries, err := client.getEntriesSince(context.Background(), "diary-1", 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, "10", sinceVersion)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "entry-new", entries[0].Id)
+}
+
+func TestGetEntriesSince_RejectsRepeatedPageToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Always points to the same next page
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openapi.GetEntriesResponse{
+			Entries:       []*openapi.Entry{{Id: "entry-1", Version: 1}},
+			NextPageToken: mo.Some("page-2"),
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	client.authToken = "token"
+
+	_, err := client.getEntriesSince(context.Background(), "diary-1", 0)
+	assert.ErrorContains(t, err, "repeated page token")
+}
+
+func (s *ClientSuite) TestChanges() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-changes-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "First"})
+	require.NoError(t, err)
+
+	initial, err := s.client.Changes(ctx, diary.ID, 0)
+	require.NoError(t, err)
+
+	// Act
+	topic, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "New topic"})
+	require.NoError(t, err)
+
+	err = s.client.DeleteEntry(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+
+	changes, err := s.client.Changes(ctx, diary.ID, initial.Version)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, initial.Changes, 1)
+	assert.Equal(t, entry.ID, initial.Changes[0].Entry.ID)
+
+	var topicChanged, entryDeleted bool
+	for _, change := range changes.Changes {
+		if change.Topic != nil && change.Topic.ID == topic.ID {
+			topicChanged = true
+		}
+		if change.Entry != nil && change.Entry.ID == entry.ID && change.Kind == ChangeDeleted {
+			entryDeleted = true
+		}
+	}
+	assert.True(t, topicChanged)
+	assert.True(t, entryDeleted)
+	assert.GreaterOrEqual(t, changes.Version, initial.Version)
+}
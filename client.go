@@ -16,6 +16,12 @@ type Client struct {
 	httpClient  *http.Client
 	authToken   string
 	userAgent   string
+
+	// revisions keeps prior entry versions, nil when revision history is disabled
+	revisions *RevisionStore
+
+	// deviceID identifies the enrolled device whose keys sign requests (empty for the account keys)
+	deviceID string
 }
 
 func NewClient(opts ...clientOption) *Client {
@@ -30,6 +36,7 @@ func NewClient(opts ...clientOption) *Client {
 			Timeout: clientOptions.timeout,
 		},
 		userAgent: buildUserAgent(),
+		revisions: clientOptions.revisions,
 	}
 
 	return &client
@@ -72,5 +79,9 @@ func (c *Client) newAuthenticatedRequest(ctx context.Context, method, url string
 
 	req.Header.Set("Authorization", "Bearer "+c.authToken)
 
+	if c.deviceID != "" {
+		req.Header.Set("X-Device-ID", c.deviceID)
+	}
+
 	return req, nil
 }
@@ -0,0 +1,257 @@
+package client
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/samber/mo"
+)
+
+// ConflictCopyTag is added to entries created by KeepBoth
+const ConflictCopyTag = "conflict"
+
+// EntryConflict describes an entry that was changed locally and remotely since
+// both sides last agreed on it
+type EntryConflict struct {
+	DiaryID string
+	EntryID string
+
+	// Base is the version the local change started from, nil when unknown
+	Base *Entry
+
+	// Local holds the change that could not be written
+	Local PutEntryParams
+
+	// LocalVersion is the version the local change was made at
+	LocalVersion uint64
+
+	// Remote is the version stored on the server
+	Remote *Entry
+}
+
+// EntryResolution is the outcome of resolving an EntryConflict
+type EntryResolution struct {
+	// Params is written as the new version of the entry; absent keeps the remote version
+	Params mo.Option[PutEntryParams]
+
+	// Copy is created as a separate entry
+	Copy mo.Option[PutEntryParams]
+}
+
+// EntryResolver resolves an EntryConflict. Resolvers return an *EntryConflictError
+// to leave the conflict to the caller.
+type EntryResolver func(conflict EntryConflict) (EntryResolution, error)
+
+// EntryConflictError reports a conflict that was left for manual resolution.
+// It matches ErrVersionConflict with errors.Is.
+type EntryConflictError struct {
+	Conflict EntryConflict
+}
+
+func (e *EntryConflictError) Error() string {
+	return fmt.Sprintf("entry %s was changed locally and remotely", e.Conflict.EntryID)
+}
+
+func (e *EntryConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// LastWriterWins keeps whichever change has the higher version
+func LastWriterWins(conflict EntryConflict) (EntryResolution, error) {
+	if conflict.LocalVersion > conflict.Remote.Version {
+		return EntryResolution{Params: mo.Some(conflict.Local)}, nil
+	}
+
+	return EntryResolution{}, nil
+}
+
+// KeepBoth keeps the remote version and stores the local change as a new entry
+// tagged with ConflictCopyTag
+func KeepBoth(conflict EntryConflict) (EntryResolution, error) {
+	conflictCopy := conflict.Local
+	conflictCopy.Tags = append(slices.Clone(conflictCopy.Tags), ConflictCopyTag)
+	conflictCopy.Attachments = slices.Clone(conflictCopy.Attachments)
+	conflictCopy.Metadata = conflictCopy.Metadata.clone()
+	conflictCopy.unknown = maps.Clone(conflictCopy.unknown)
+
+	return EntryResolution{Copy: mo.Some(conflictCopy)}, nil
+}
+
+// FieldMerge combines both changes field by field, taking each field from the side
+// that changed it. Fields changed differently on both sides, as well as conflicts
+// without a known base, are left to the caller.
+func FieldMerge(conflict EntryConflict) (EntryResolution, error) {
+	return mergeEntryFields(conflict, func(base, local, remote string) (string, bool) {
+		return "", false
+	})
+}
+
+// ThreeWayMerge works like FieldMerge but also merges content changed on both
+// sides line by line, as long as the changes do not touch the same lines
+func ThreeWayMerge(conflict EntryConflict) (EntryResolution, error) {
+	return mergeEntryFields(conflict, mergeLines)
+}
+
+// Manual leaves every conflict to the caller
+func Manual(conflict EntryConflict) (EntryResolution, error) {
+	return EntryResolution{}, &EntryConflictError{Conflict: conflict}
+}
+
+// mergeEntryFields merges the fields of both sides, resolving content changed on
+// both sides with mergeContent
+func mergeEntryFields(
+	conflict EntryConflict,
+	mergeContent func(base, local, remote string) (string, bool),
+) (EntryResolution, error) {
+	if conflict.Base == nil {
+		return EntryResolution{}, &EntryConflictError{Conflict: conflict}
+	}
+
+	base := conflict.Base.PutParams()
+	local := conflict.Local
+	merged := conflict.Remote.PutParams()
+
+	conflicting := false
+	pick := func(baseValue, localValue, remoteValue any, set func()) {
+		switch {
+		case reflect.DeepEqual(localValue, baseValue), reflect.DeepEqual(localValue, remoteValue):
+		case reflect.DeepEqual(remoteValue, baseValue):
+			set()
+		default:
+			conflicting = true
+		}
+	}
+
+	pick(base.TopicID, local.TopicID, merged.TopicID, func() { merged.TopicID = local.TopicID })
+	pick(base.Archived, local.Archived, merged.Archived, func() { merged.Archived = local.Archived })
+	pick(base.Bookmarked, local.Bookmarked, merged.Bookmarked, func() { merged.Bookmarked = local.Bookmarked })
+	pick(base.PreviewHidden, local.PreviewHidden, merged.PreviewHidden, func() { merged.PreviewHidden = local.PreviewHidden })
+	pick(base.Attachments, local.Attachments, merged.Attachments, func() { merged.Attachments = local.Attachments })
+	pick(base.Tags, local.Tags, merged.Tags, func() { merged.Tags = local.Tags })
+	pick(base.Metadata, local.Metadata, merged.Metadata, func() { merged.Metadata = local.Metadata })
+	pick(base.AuthoredAt, local.AuthoredAt, merged.AuthoredAt, func() { merged.AuthoredAt = local.AuthoredAt })
+
+	switch {
+	case local.Content == base.Content || local.Content == merged.Content:
+	case merged.Content == base.Content:
+		merged.Content = local.Content
+	default:
+		content, ok := mergeContent(base.Content, local.Content, merged.Content)
+		if !ok {
+			conflicting = true
+		}
+		merged.Content = content
+	}
+
+	if conflicting {
+		return EntryResolution{}, &EntryConflictError{Conflict: conflict}
+	}
+
+	return EntryResolution{Params: mo.Some(merged)}, nil
+}
+
+// lineEdit replaces the base lines [start, end) with lines
+type lineEdit struct {
+	start, end int
+	lines      []string
+	local      bool
+}
+
+// lineEdits returns the edits turning base into other
+func lineEdits(base, other string, local bool) []lineEdit {
+	var edits []lineEdit
+	pos := 0
+	for _, chunk := range DiffLines(base, other) {
+		lines := splitLines(chunk.Text)
+
+		switch chunk.Op {
+		case DiffEqual:
+			pos += len(lines)
+		case DiffDelete:
+			edits = append(edits, lineEdit{start: pos, end: pos + len(lines), local: local})
+			pos += len(lines)
+		case DiffInsert:
+			// An insertion right after a deletion replaces the deleted lines
+			if n := len(edits); n > 0 && edits[n-1].end == pos && edits[n-1].lines == nil {
+				edits[n-1].lines = lines
+			} else {
+				edits = append(edits, lineEdit{start: pos, end: pos, lines: lines, local: local})
+			}
+		}
+	}
+
+	return edits
+}
+
+// mergeLines merges the changes of local and remote to base. It fails when both
+// sides changed the same lines differently.
+func mergeLines(base, local, remote string) (string, bool) {
+	baseLines := splitLines(base)
+
+	edits := append(lineEdits(base, local, true), lineEdits(base, remote, false)...)
+	slices.SortStableFunc(edits, func(a, b lineEdit) int {
+		return a.start - b.start
+	})
+
+	// applyEdits applies the edits of one side to the base lines [start, end)
+	applyEdits := func(group []lineEdit, local bool, start, end int) string {
+		var sb strings.Builder
+		pos := start
+		for _, edit := range group {
+			if edit.local != local {
+				continue
+			}
+
+			sb.WriteString(strings.Join(baseLines[pos:edit.start], ""))
+			sb.WriteString(strings.Join(edit.lines, ""))
+			pos = edit.end
+		}
+		sb.WriteString(strings.Join(baseLines[pos:end], ""))
+
+		return sb.String()
+	}
+
+	var sb strings.Builder
+	pos := 0
+	for i := 0; i < len(edits); {
+		// Group edits touching overlapping or identical base positions
+		start, end := edits[i].start, edits[i].end
+		hasLocal, hasRemote := false, false
+		j := i
+		for ; j < len(edits); j++ {
+			edit := edits[j]
+			joins := j == i || edit.start < end || edit.start == start || (edit.start == end && edit.start == edit.end)
+			if !joins {
+				break
+			}
+
+			end = max(end, edit.end)
+			hasLocal = hasLocal || edit.local
+			hasRemote = hasRemote || !edit.local
+		}
+
+		group := edits[i:j]
+		localResult := applyEdits(group, true, start, end)
+		remoteResult := applyEdits(group, false, start, end)
+
+		sb.WriteString(strings.Join(baseLines[pos:start], ""))
+		switch {
+		case !hasRemote:
+			sb.WriteString(localResult)
+		case !hasLocal || localResult == remoteResult:
+			sb.WriteString(remoteResult)
+		default:
+			return "", false
+		}
+
+		pos = end
+		i = j
+	}
+
+	sb.WriteString(strings.Join(baseLines[pos:], ""))
+
+	return sb.String(), true
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeLines(t *testing.T) {
+	base := "one\ntwo\nthree\nfour\n"
+
+	tests := []struct {
+		name   string
+		local  string
+		remote string
+		want   string
+		ok     bool
+	}{
+		{
+			name:   "separate lines",
+			local:  "ONE\ntwo\nthree\nfour\n",
+			remote: "one\ntwo\nthree\nFOUR\n",
+			want:   "ONE\ntwo\nthree\nFOUR\n",
+			ok:     true,
+		},
+		{
+			name:   "insertions at different places",
+			local:  "zero\none\ntwo\nthree\nfour\n",
+			remote: "one\ntwo\nthree\nfour\nfive\n",
+			want:   "zero\none\ntwo\nthree\nfour\nfive\n",
+			ok:     true,
+		},
+		{
+			name:   "same change on both sides",
+			local:  "one\n2\nthree\nfour\n",
+			remote: "one\n2\nthree\nfour\n",
+			want:   "one\n2\nthree\nfour\n",
+			ok:     true,
+		},
+		{
+			name:   "different changes to the same line",
+			local:  "one\nlocal\nthree\nfour\n",
+			remote: "one\nremote\nthree\nfour\n",
+			ok:     false,
+		},
+		{
+			name:   "insertions at the same place",
+			local:  "one\nlocal\ntwo\nthree\nfour\n",
+			remote: "one\nremote\ntwo\nthree\nfour\n",
+			ok:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := mergeLines(base, tt.local, tt.remote)
+
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLastWriterWins(t *testing.T) {
+	conflict := EntryConflict{
+		Local:        PutEntryParams{Content: "local"},
+		LocalVersion: 10,
+		Remote:       &Entry{Content: "remote", Version: 5},
+	}
+
+	resolution, err := LastWriterWins(conflict)
+	require.NoError(t, err)
+	assert.Equal(t, "local", resolution.Params.MustGet().Content)
+
+	conflict.Remote.Version = 20
+	resolution, err = LastWriterWins(conflict)
+	require.NoError(t, err)
+	assert.True(t, resolution.Params.IsAbsent())
+}
+
+func TestKeepBoth(t *testing.T) {
+	conflict := EntryConflict{
+		Local:  PutEntryParams{Content: "local", Tags: []string{"travel"}},
+		Remote: &Entry{Content: "remote"},
+	}
+
+	resolution, err := KeepBoth(conflict)
+	require.NoError(t, err)
+
+	assert.True(t, resolution.Params.IsAbsent())
+	assert.Equal(t, []string{"travel", ConflictCopyTag}, resolution.Copy.MustGet().Tags)
+	assert.Equal(t, []string{"travel"}, conflict.Local.Tags)
+}
+
+func TestFieldMerge(t *testing.T) {
+	base := &Entry{Content: "text", Tags: []string{"a"}}
+	conflict := EntryConflict{
+		Base:   base,
+		Local:  PutEntryParams{Content: "text", Bookmarked: true, Tags: []string{"a"}},
+		Remote: &Entry{Content: "text", Archived: true, Tags: []string{"a", "b"}},
+	}
+
+	resolution, err := FieldMerge(conflict)
+	require.NoError(t, err)
+
+	merged := resolution.Params.MustGet()
+	assert.True(t, merged.Bookmarked)
+	assert.True(t, merged.Archived)
+	assert.Equal(t, []string{"a", "b"}, merged.Tags)
+}
+
+func TestFieldMerge_Conflict(t *testing.T) {
+	conflict := EntryConflict{
+		EntryID: "entry-1",
+		Base:    &Entry{Content: "line\n"},
+		Local:   PutEntryParams{Content: "local line\n"},
+		Remote:  &Entry{Content: "remote line\n"},
+	}
+
+	_, err := FieldMerge(conflict)
+
+	var conflictErr *EntryConflictError
+	require.True(t, errors.As(err, &conflictErr))
+	assert.Equal(t, "entry-1", conflictErr.Conflict.EntryID)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+}
+
+func TestThreeWayMerge(t *testing.T) {
+	conflict := EntryConflict{
+		Base:   &Entry{Content: "title\n\nbody\n"},
+		Local:  PutEntryParams{Content: "Title\n\nbody\n"},
+		Remote: &Entry{Content: "title\n\nbody\nmore\n"},
+	}
+
+	resolution, err := ThreeWayMerge(conflict)
+	require.NoError(t, err)
+	assert.Equal(t, "Title\n\nbody\nmore\n", resolution.Params.MustGet().Content)
+
+	conflict.Base = nil
+	_, err = ThreeWayMerge(conflict)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+}
+
+func TestManual(t *testing.T) {
+	_, err := Manual(EntryConflict{Remote: &Entry{}})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+}
@@ -20,7 +20,10 @@ type CreateDiaryParams struct {
 
 // GetDiaryDetails extracts diary details from parameters
 func (p CreateDiaryParams) GetDiaryDetails() DiaryDetails {
-	return DiaryDetails(p)
+	return DiaryDetails{
+		Title:       p.Title,
+		Description: p.Description,
+	}
 }
 
 // CreateDiary creates a new diary with zero-knowledge encryption
@@ -164,6 +167,7 @@ func (c *Client) CreateDiary(ctx context.Context, params CreateDiaryParams) (*Di
 		CreatedAt:   apiResponse.Diary.CreatedAt,
 		UpdatedAt:   apiResponse.Diary.UpdatedAt,
 		Version:     apiResponse.Diary.Version,
+		unknown:     decryptedDiaryDetails.unknown,
 	}
 
 	return &diary, nil
@@ -2,8 +2,10 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/samber/mo"
 )
 
@@ -13,12 +15,38 @@ type CreateEntryParams struct {
 	Archived      bool
 	Bookmarked    bool
 	PreviewHidden bool
+	Attachments   []AttachmentRef
+	Tags          []string
+	Metadata      EntryMetadata
+	AuthoredAt    mo.Option[time.Time]
 }
 
+// CreateEntry creates an entry with a new ID. When the content is empty and the
+// entry belongs to a topic with a default template, the rendered template is used
+// as content. A template that fails to render is used as is.
 func (c *Client) CreateEntry(ctx context.Context, diaryID string, params CreateEntryParams) (*Entry, error) {
 	entryID := uuid.NewString()
 
-	putParams := PutEntryParams(params)
+	if topicID, ok := params.TopicID.Get(); ok && params.Content == "" {
+		content, err := c.renderDefaultTemplate(ctx, diaryID, topicID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to apply default template")
+		}
+
+		params.Content = content
+	}
+
+	putParams := PutEntryParams{
+		Content:       params.Content,
+		TopicID:       params.TopicID,
+		Archived:      params.Archived,
+		Bookmarked:    params.Bookmarked,
+		PreviewHidden: params.PreviewHidden,
+		Attachments:   params.Attachments,
+		Tags:          params.Tags,
+		Metadata:      params.Metadata,
+		AuthoredAt:    params.AuthoredAt,
+	}
 
 	return c.PutEntry(ctx, diaryID, entryID, putParams)
 }
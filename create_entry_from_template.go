@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// CreateEntryFromTemplate creates an entry whose content is the template rendered
+// with vars. See RenderTemplate for the template syntax.
+func (c *Client) CreateEntryFromTemplate(ctx context.Context, diaryID, templateID string, vars map[string]string) (*Entry, error) {
+	template, err := c.GetTemplateByID(ctx, diaryID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := RenderTemplate(template.Content, vars)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render template %s", templateID)
+	}
+
+	return c.CreateEntry(ctx, diaryID, CreateEntryParams{Content: content})
+}
+
+// renderDefaultTemplate renders the default template of a topic. It returns an
+// empty string when the topic has no default template or it was deleted, and the
+// raw template content when it does not render, so a broken template never
+// prevents creating an entry.
+func (c *Client) renderDefaultTemplate(ctx context.Context, diaryID, topicID string) (string, error) {
+	topic, err := c.GetTopicByID(ctx, diaryID, topicID)
+	if err != nil {
+		return "", err
+	}
+
+	templateID, ok := topic.DefaultTemplateID.Get()
+	if !ok {
+		return "", nil
+	}
+
+	template, err := c.GetTemplateByID(ctx, diaryID, templateID)
+	if errors.Is(err, ErrTemplateNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if template.DeletedAt.IsPresent() {
+		return "", nil
+	}
+
+	content, err := RenderTemplate(template.Content, nil)
+	if err != nil {
+		return template.Content, nil
+	}
+
+	return content, nil
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestEntry_CreateEntryFromTemplate() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-entry-from-template-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	template, err := s.client.CreateTemplate(ctx, diary.ID, CreateTemplateParams{
+		Content: "Meeting with {{person}}{{#if project}} about {{project}}{{/if}}",
+	})
+	require.NoError(t, err)
+
+	// Act
+	entry, err := s.client.CreateEntryFromTemplate(ctx, diary.ID, template.ID, map[string]string{"person": "Grace"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Meeting with Grace", entry.Content)
+}
+
+func (s *ClientSuite) TestEntry_CreateEntry_AppliesTopicDefaultTemplate() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-entry-default-template-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	template, err := s.client.CreateTemplate(ctx, diary.ID, CreateTemplateParams{Content: "Standup on {{weekday}}"})
+	require.NoError(t, err)
+
+	topic, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{
+		Title:             "Work",
+		DefaultTemplateID: mo.Some(template.ID),
+	})
+	require.NoError(t, err)
+
+	// Act
+	templated, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{TopicID: mo.Some(topic.ID)})
+	require.NoError(t, err)
+
+	explicit, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{TopicID: mo.Some(topic.ID), Content: "Own words"})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Contains(t, templated.Content, "Standup on ")
+	assert.NotContains(t, templated.Content, "{{")
+	assert.Equal(t, "Own words", explicit.Content)
+}
+
+func (s *ClientSuite) TestEntry_CreateEntry_UnrenderableDefaultTemplate() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-entry-broken-template-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	template, err := s.client.CreateTemplate(ctx, diary.ID, CreateTemplateParams{Content: "Use {{ to open a placeholder"})
+	require.NoError(t, err)
+
+	topic, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{
+		Title:             "Notes",
+		DefaultTemplateID: mo.Some(template.ID),
+	})
+	require.NoError(t, err)
+
+	// Act
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{TopicID: mo.Some(topic.ID)})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Use {{ to open a placeholder", entry.Content)
+}
@@ -7,13 +7,25 @@ import (
 )
 
 type CreateTemplateParams struct {
-	Content string
+	Name        string
+	Description string
+	Icon        string
+	Color       string
+	Content     string
+	Prompts     []string
 }
 
 func (c *Client) CreateTemplate(ctx context.Context, diaryID string, params CreateTemplateParams) (*Template, error) {
 	templateID := uuid.NewString()
 
-	putParams := PutTemplateParams(params)
+	putParams := PutTemplateParams{
+		Name:        params.Name,
+		Description: params.Description,
+		Icon:        params.Icon,
+		Color:       params.Color,
+		Content:     params.Content,
+		Prompts:     params.Prompts,
+	}
 
 	return c.PutTemplate(ctx, diaryID, templateID, putParams)
 }
@@ -12,12 +12,19 @@ type CreateTopicParams struct {
 	Description       string
 	Color             string
 	DefaultTemplateID mo.Option[string]
+	ParentID          mo.Option[string]
 }
 
 func (c *Client) CreateTopic(ctx context.Context, diaryID string, params CreateTopicParams) (*Topic, error) {
 	topicID := uuid.NewString()
 
-	putParams := PutTopicParams(params)
+	putParams := PutTopicParams{
+		Title:             params.Title,
+		Description:       params.Description,
+		Color:             params.Color,
+		DefaultTemplateID: params.DefaultTemplateID,
+		ParentID:          params.ParentID,
+	}
 
 	return c.PutTopic(ctx, diaryID, topicID, putParams)
 }
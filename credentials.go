@@ -2,8 +2,10 @@ package client
 
 import (
 	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/sha256"
 
+	"github.com/pkg/errors"
 	"golang.org/x/crypto/curve25519"
 	"golang.org/x/crypto/pbkdf2"
 )
@@ -21,7 +23,26 @@ func NewCredentials(seedPhrase string) (*Credentials, error) {
 	salt := []byte("my-app-context")
 
 	seed := pbkdf2.Key([]byte(seedPhrase), salt, 100_000, 32, sha256.New)
+	defer clear(seed)
 
+	return newCredentialsFromSeed(seed), nil
+}
+
+// NewDeviceCredentials generates a random key pair for a single device.
+// Unlike account credentials it cannot be re-derived, so it has to be stored
+// on the device, e.g. with Credentials.MarshalEncrypted.
+func NewDeviceCredentials() (*Credentials, error) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, errors.Wrap(err, "failed to generate device seed")
+	}
+	defer clear(seed)
+
+	return newCredentialsFromSeed(seed), nil
+}
+
+// newCredentialsFromSeed derives the encryption and signing key pairs from a 32-byte seed
+func newCredentialsFromSeed(seed []byte) *Credentials {
 	var privateKey [32]byte
 	copy(privateKey[:], seed[:32])
 	var publicKey [32]byte
@@ -39,5 +60,5 @@ func NewCredentials(seedPhrase string) (*Credentials, error) {
 		SigningPrivateKey: signPrivateKey,
 	}
 
-	return &creds, nil
+	return &creds
 }
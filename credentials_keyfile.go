@@ -0,0 +1,266 @@
+package client
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/curve25519"
+)
+
+const (
+	keyfileVersion = 1
+	keyfileKDF     = "argon2id"
+	keyfileCipher  = "aes-256-gcm"
+
+	// Argon2id parameters following the RFC 9106 second recommended option
+	keyfileKDFTime    = 3
+	keyfileKDFMemory  = 64 * 1024
+	keyfileKDFThreads = 4
+
+	// keyfileMaxKDFMemory bounds memory usage when opening untrusted keyfiles (1 GiB)
+	keyfileMaxKDFMemory = 1024 * 1024
+
+	// keyfileMaxKDFTime bounds the Argon2id passes when opening untrusted keyfiles
+	keyfileMaxKDFTime = 16
+)
+
+// keyfile is the serialized form of password protected credentials.
+// Public keys are kept in clear so a keyfile can be identified without the passphrase.
+type keyfile struct {
+	Version             int           `json:"version"`
+	KDF                 keyfileParams `json:"kdf"`
+	Cipher              string        `json:"cipher"`
+	SigningPublicKey    []byte        `json:"signing_public_key"`
+	EncryptionPublicKey []byte        `json:"encryption_public_key"`
+	Nonce               []byte        `json:"nonce"`
+	Ciphertext          []byte        `json:"ciphertext"`
+}
+
+// keyfileHeader is the clear part of a keyfile. It is authenticated as additional
+// data of the encrypted part, so it cannot be altered without detection.
+type keyfileHeader struct {
+	Version             int           `json:"version"`
+	KDF                 keyfileParams `json:"kdf"`
+	Cipher              string        `json:"cipher"`
+	SigningPublicKey    []byte        `json:"signing_public_key"`
+	EncryptionPublicKey []byte        `json:"encryption_public_key"`
+}
+
+func (kf keyfile) header() keyfileHeader {
+	return keyfileHeader{
+		Version:             kf.Version,
+		KDF:                 kf.KDF,
+		Cipher:              kf.Cipher,
+		SigningPublicKey:    kf.SigningPublicKey,
+		EncryptionPublicKey: kf.EncryptionPublicKey,
+	}
+}
+
+// additionalData returns the header encoding passed to GCM as additional data
+func (h keyfileHeader) additionalData() ([]byte, error) {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal keyfile header")
+	}
+
+	return data, nil
+}
+
+// keyfileParams holds the key derivation parameters of a keyfile
+type keyfileParams struct {
+	Name    string `json:"name"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+// keyfileSecrets is the encrypted part of a keyfile
+type keyfileSecrets struct {
+	EncryptionPrivateKey []byte `json:"encryption_private_key"`
+	SigningPrivateKey    []byte `json:"signing_private_key"`
+}
+
+// MarshalEncrypted serializes credentials into a keyfile protected by passphrase
+func (c *Credentials) MarshalEncrypted(passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase must not be empty")
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+
+	params := keyfileParams{
+		Name:    keyfileKDF,
+		Salt:    salt,
+		Time:    keyfileKDFTime,
+		Memory:  keyfileKDFMemory,
+		Threads: keyfileKDFThreads,
+	}
+
+	secretsJSON, err := json.Marshal(keyfileSecrets{
+		EncryptionPrivateKey: c.EncryptionPrivateKey,
+		SigningPrivateKey:    c.SigningPrivateKey,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal private keys")
+	}
+	defer clear(secretsJSON)
+
+	kf := keyfile{
+		Version:             keyfileVersion,
+		KDF:                 params,
+		Cipher:              keyfileCipher,
+		SigningPublicKey:    c.SigningPublicKey,
+		EncryptionPublicKey: c.EncryptionPublicKey,
+	}
+
+	additionalData, err := kf.header().additionalData()
+	if err != nil {
+		return nil, err
+	}
+
+	key := params.deriveKey(passphrase)
+	defer clear(key)
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	kf.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(kf.Nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+
+	kf.Ciphertext = gcm.Seal(nil, kf.Nonce, secretsJSON, additionalData)
+
+	data, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal keyfile")
+	}
+
+	return data, nil
+}
+
+// UnmarshalCredentials opens a keyfile produced by Credentials.MarshalEncrypted
+func UnmarshalCredentials(data []byte, passphrase string) (*Credentials, error) {
+	var kf keyfile
+	if err := json.Unmarshal(data, &kf); err != nil {
+		return nil, errors.Wrap(err, "failed to parse keyfile")
+	}
+
+	if kf.Version != keyfileVersion {
+		return nil, errors.Errorf("unsupported keyfile version: %d", kf.Version)
+	}
+
+	if kf.Cipher != keyfileCipher {
+		return nil, errors.Errorf("unsupported keyfile cipher: %s", kf.Cipher)
+	}
+
+	if err := kf.KDF.validate(); err != nil {
+		return nil, err
+	}
+
+	additionalData, err := kf.header().additionalData()
+	if err != nil {
+		return nil, err
+	}
+
+	key := kf.KDF.deriveKey(passphrase)
+	defer clear(key)
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(kf.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid keyfile nonce")
+	}
+
+	// A wrong passphrase and an altered header fail the same way
+	secretsJSON, err := gcm.Open(nil, kf.Nonce, kf.Ciphertext, additionalData)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	defer clear(secretsJSON)
+
+	var secrets keyfileSecrets
+	if err := json.Unmarshal(secretsJSON, &secrets); err != nil {
+		return nil, errors.Wrap(err, "failed to parse private keys")
+	}
+
+	if len(secrets.EncryptionPrivateKey) != curve25519.ScalarSize {
+		return nil, errors.New("invalid encryption private key")
+	}
+
+	if len(secrets.SigningPrivateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid signing private key")
+	}
+
+	encryptionPublicKey, err := curve25519.X25519(secrets.EncryptionPrivateKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive encryption public key")
+	}
+
+	signingPrivateKey := ed25519.PrivateKey(secrets.SigningPrivateKey)
+	signingPublicKey := signingPrivateKey.Public().(ed25519.PublicKey)
+
+	// Public keys are stored in clear, so make sure they were not swapped
+	if !bytes.Equal(encryptionPublicKey, kf.EncryptionPublicKey) || !bytes.Equal(signingPublicKey, kf.SigningPublicKey) {
+		return nil, errors.New("keyfile public keys do not match private keys")
+	}
+
+	creds := Credentials{
+		EncryptionPublicKey:  encryptionPublicKey,
+		EncryptionPrivateKey: secrets.EncryptionPrivateKey,
+
+		SigningPublicKey:  signingPublicKey,
+		SigningPrivateKey: signingPrivateKey,
+	}
+
+	return &creds, nil
+}
+
+// Zero overwrites private key bytes so they do not linger in memory after use
+func (c *Credentials) Zero() {
+	clear(c.EncryptionPrivateKey)
+	clear(c.SigningPrivateKey)
+}
+
+// deriveKey derives the keyfile encryption key from passphrase
+func (p keyfileParams) deriveKey(passphrase string) []byte {
+	return argon2.IDKey([]byte(passphrase), p.Salt, p.Time, p.Memory, p.Threads, 32)
+}
+
+// validate rejects unknown or unreasonable key derivation parameters
+func (p keyfileParams) validate() error {
+	if p.Name != keyfileKDF {
+		return errors.Errorf("unsupported keyfile kdf: %s", p.Name)
+	}
+
+	if len(p.Salt) < 16 {
+		return errors.New("keyfile salt is too short")
+	}
+
+	if p.Time == 0 || p.Threads == 0 || p.Memory == 0 {
+		return errors.New("invalid keyfile kdf parameters")
+	}
+
+	if p.Memory > keyfileMaxKDFMemory {
+		return errors.Errorf("keyfile kdf memory %d KiB exceeds limit", p.Memory)
+	}
+
+	if p.Time > keyfileMaxKDFTime {
+		return errors.Errorf("keyfile kdf time %d exceeds limit", p.Time)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentials_MarshalEncrypted(t *testing.T) {
+	creds, err := NewCredentials("keyfile test seed phrase")
+	require.NoError(t, err)
+
+	data, err := creds.MarshalEncrypted("correct horse battery staple")
+	require.NoError(t, err)
+
+	t.Run("public keys are readable without passphrase", func(t *testing.T) {
+		var kf keyfile
+		require.NoError(t, json.Unmarshal(data, &kf))
+		assert.Equal(t, keyfileVersion, kf.Version)
+		assert.Equal(t, "argon2id", kf.KDF.Name)
+		assert.Equal(t, creds.SigningPublicKey, kf.SigningPublicKey)
+		assert.Equal(t, creds.EncryptionPublicKey, kf.EncryptionPublicKey)
+		assert.NotContains(t, string(data), "private")
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		restored, err := UnmarshalCredentials(data, "correct horse battery staple")
+		require.NoError(t, err)
+		assert.Equal(t, creds, restored)
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		restored, err := UnmarshalCredentials(data, "wrong passphrase")
+		assert.ErrorIs(t, err, ErrInvalidPassphrase)
+		assert.Nil(t, restored)
+	})
+
+	t.Run("swapped public key", func(t *testing.T) {
+		other, err := NewCredentials("another seed phrase")
+		require.NoError(t, err)
+
+		var kf keyfile
+		require.NoError(t, json.Unmarshal(data, &kf))
+		kf.EncryptionPublicKey = other.EncryptionPublicKey
+
+		tampered, err := json.Marshal(kf)
+		require.NoError(t, err)
+
+		_, err = UnmarshalCredentials(tampered, "correct horse battery staple")
+		require.Error(t, err)
+	})
+
+	t.Run("excessive kdf memory", func(t *testing.T) {
+		var kf keyfile
+		require.NoError(t, json.Unmarshal(data, &kf))
+		kf.KDF.Memory = keyfileMaxKDFMemory + 1
+
+		tampered, err := json.Marshal(kf)
+		require.NoError(t, err)
+
+		_, err = UnmarshalCredentials(tampered, "correct horse battery staple")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds limit")
+	})
+
+	t.Run("excessive kdf time", func(t *testing.T) {
+		var kf keyfile
+		require.NoError(t, json.Unmarshal(data, &kf))
+		kf.KDF.Time = keyfileMaxKDFTime + 1
+
+		tampered, err := json.Marshal(kf)
+		require.NoError(t, err)
+
+		_, err = UnmarshalCredentials(tampered, "correct horse battery staple")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds limit")
+	})
+
+	t.Run("header is authenticated", func(t *testing.T) {
+		var kf keyfile
+		require.NoError(t, json.Unmarshal(data, &kf))
+
+		gcm, err := newGCM(kf.KDF.deriveKey("correct horse battery staple"))
+		require.NoError(t, err)
+
+		additionalData, err := kf.header().additionalData()
+		require.NoError(t, err)
+
+		_, err = gcm.Open(nil, kf.Nonce, kf.Ciphertext, additionalData)
+		require.NoError(t, err)
+
+		altered := kf.header()
+		altered.SigningPublicKey = append([]byte{0}, altered.SigningPublicKey[1:]...)
+		alteredData, err := altered.additionalData()
+		require.NoError(t, err)
+
+		_, err = gcm.Open(nil, kf.Nonce, kf.Ciphertext, alteredData)
+		assert.Error(t, err)
+
+		_, err = gcm.Open(nil, kf.Nonce, kf.Ciphertext, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestCredentials_Zero(t *testing.T) {
+	creds, err := NewCredentials("zero test seed phrase")
+	require.NoError(t, err)
+
+	creds.Zero()
+
+	assert.Equal(t, make([]byte, len(creds.EncryptionPrivateKey)), creds.EncryptionPrivateKey)
+	assert.Equal(t, make([]byte, len(creds.SigningPrivateKey)), creds.SigningPrivateKey)
+	assert.NotEqual(t, make([]byte, len(creds.SigningPublicKey)), creds.SigningPublicKey)
+}
@@ -7,10 +7,15 @@ import (
 	"net/url"
 
 	"github.com/pkg/errors"
+	"github.com/samber/mo"
 )
 
 type DeleteTopicParams struct {
 	DeleteEntries bool
+
+	// DeleteChildren deletes nested topics as well, applying DeleteEntries to each of
+	// them. Otherwise nested topics are moved to the parent of the deleted topic.
+	DeleteChildren bool
 }
 
 func (c *Client) DeleteTopic(ctx context.Context, diaryID string, topicID string, params ...DeleteTopicParams) error {
@@ -23,6 +28,68 @@ func (c *Client) DeleteTopic(ctx context.Context, diaryID string, topicID string
 		p = params[0]
 	}
 
+	topics, err := c.GetTopics(ctx, diaryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get topics")
+	}
+
+	if err := c.deleteChildTopics(ctx, diaryID, topicID, p, topics, map[string]bool{topicID: true}); err != nil {
+		return err
+	}
+
+	return c.deleteTopic(ctx, diaryID, topicID, p)
+}
+
+// deleteChildTopics deletes or reparents the children of a topic before it is deleted
+func (c *Client) deleteChildTopics(
+	ctx context.Context,
+	diaryID, topicID string,
+	p DeleteTopicParams,
+	topics []*Topic,
+	visited map[string]bool,
+) error {
+	var newParentID string
+	for _, topic := range topics {
+		if topic.ID == topicID {
+			newParentID = topic.ParentID.OrEmpty()
+			break
+		}
+	}
+
+	for _, child := range topics {
+		if child.ParentID.OrEmpty() != topicID || child.DeletedAt.IsPresent() {
+			continue
+		}
+
+		if visited[child.ID] {
+			return errors.Wrapf(ErrTopicCycle, "topic %s", child.ID)
+		}
+		visited[child.ID] = true
+
+		if p.DeleteChildren {
+			if err := c.deleteChildTopics(ctx, diaryID, child.ID, p, topics, visited); err != nil {
+				return err
+			}
+
+			if err := c.deleteTopic(ctx, diaryID, child.ID, p); err != nil {
+				return errors.Wrapf(err, "failed to delete child topic %s", child.ID)
+			}
+
+			continue
+		}
+
+		putParams := child.PutParams()
+		putParams.ParentID = mo.EmptyableToOption(newParentID)
+
+		if _, err := c.PutTopic(ctx, diaryID, child.ID, putParams); err != nil {
+			return errors.Wrapf(err, "failed to move child topic %s", child.ID)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) deleteTopic(ctx context.Context, diaryID, topicID string, p DeleteTopicParams) error {
 	urlStr := fmt.Sprintf("%s/v1/diaries/%s/topics/%s", c.baseURL, diaryID, topicID)
 	u, err := url.Parse(urlStr)
 	if err != nil {
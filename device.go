@@ -0,0 +1,48 @@
+package client
+
+import (
+	"time"
+
+	"github.com/samber/mo"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// Device represents a device enrolled with its own signing and encryption key pair
+type Device struct {
+	ID                  string
+	Name                string
+	SigningPublicKey    []byte
+	EncryptionPublicKey []byte
+	CreatedAt           time.Time
+	LastSeenAt          mo.Option[time.Time]
+}
+
+// AccountKeys returns the public keys identifying the device
+func (d *Device) AccountKeys() AccountKeys {
+	return AccountKeys{
+		SigningPublicKey:    d.SigningPublicKey,
+		EncryptionPublicKey: d.EncryptionPublicKey,
+	}
+}
+
+// convertDevice converts an API device to its client representation
+func convertDevice(apiDevice *openapi.Device) *Device {
+	return &Device{
+		ID:                  apiDevice.Id,
+		Name:                apiDevice.Name,
+		SigningPublicKey:    apiDevice.SignaturePublicKey,
+		EncryptionPublicKey: apiDevice.EncryptionPublicKey,
+		CreatedAt:           apiDevice.CreatedAt,
+		LastSeenAt:          apiDevice.LastSeenAt,
+	}
+}
+
+// deviceAuthorizationMessage builds the message the account signs to vouch for device keys
+func deviceAuthorizationMessage(signingPublicKey, encryptionPublicKey []byte) []byte {
+	message := []byte("thingsdiary-device-authorization")
+	message = append(message, signingPublicKey...)
+	message = append(message, encryptionPublicKey...)
+
+	return message
+}
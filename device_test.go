@@ -0,0 +1,28 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeviceCredentials(t *testing.T) {
+	first, err := NewDeviceCredentials()
+	require.NoError(t, err)
+
+	second, err := NewDeviceCredentials()
+	require.NoError(t, err)
+
+	assert.Len(t, first.EncryptionPublicKey, 32)
+	assert.Len(t, first.SigningPublicKey, 32)
+	assert.False(t, first.AccountKeys().Equal(second.AccountKeys()))
+
+	// Device keys are random, so they must survive a keyfile round trip
+	data, err := first.MarshalEncrypted("device passphrase")
+	require.NoError(t, err)
+
+	restored, err := UnmarshalCredentials(data, "device passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, first, restored)
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"encoding/json"
+	"maps"
 	"time"
 
 	"github.com/pkg/errors"
@@ -17,12 +18,46 @@ type Diary struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	Version     uint64
+
+	// unknown holds details fields written by newer clients
+	unknown unknownFields
 }
 
 // DiaryDetails represents the plaintext content structure for diary details
 type DiaryDetails struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
+
+	unknown unknownFields
+}
+
+func (d DiaryDetails) MarshalJSON() ([]byte, error) {
+	type plain DiaryDetails
+	return marshalWithUnknown(plain(d), d.unknown)
+}
+
+func (d *DiaryDetails) UnmarshalJSON(data []byte) error {
+	type plain DiaryDetails
+
+	var p plain
+	unknown, err := unmarshalWithUnknown(data, &p)
+	if err != nil {
+		return err
+	}
+
+	*d = DiaryDetails(p)
+	d.unknown = unknown
+
+	return nil
+}
+
+// PutParams returns the parameters that store the diary unchanged with PutDiary
+func (d *Diary) PutParams() PutDiaryParams {
+	return PutDiaryParams{
+		Title:       d.Title,
+		Description: d.Description,
+		unknown:     maps.Clone(d.unknown),
+	}
 }
 
 // decryptDiary decrypts a diary using the provided credentials
@@ -35,7 +70,16 @@ func (c *Client) decryptDiary(diaryData *openapi.Diary) (*Diary, error) {
 		return nil, errors.New("no encryption keys found in response")
 	}
 
+	// Diary details are encrypted under the key they reference, which is not
+	// necessarily the first one after a key rotation
 	encryptedDiaryKeyValue := diaryData.EncryptionKeys[0].Value
+	for _, key := range diaryData.EncryptionKeys {
+		if key.Id == diaryData.Encryption.DiaryKeyId {
+			encryptedDiaryKeyValue = key.Value
+			break
+		}
+	}
+
 	decryptedDiaryKey, err := decryptWithPrivateKey(
 		encryptedDiaryKeyValue,
 		c.credentials.EncryptionPrivateKey,
@@ -75,6 +119,7 @@ func (c *Client) decryptDiary(diaryData *openapi.Diary) (*Diary, error) {
 		CreatedAt:   diaryData.CreatedAt,
 		UpdatedAt:   diaryData.UpdatedAt,
 		Version:     diaryData.Version,
+		unknown:     decryptedDiaryDetails.unknown,
 	}
 
 	return diary, nil
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"time"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// DiaryRole defines what a member is allowed to do with a shared diary
+type DiaryRole string
+
+const (
+	DiaryRoleOwner  DiaryRole = "owner"
+	DiaryRoleEditor DiaryRole = "editor"
+	DiaryRoleViewer DiaryRole = "viewer"
+)
+
+// DiaryMember represents an account with access to a diary
+type DiaryMember struct {
+	AccountID           string
+	Role                DiaryRole
+	EncryptionPublicKey []byte
+	SigningPublicKey    []byte
+	CreatedAt           time.Time
+}
+
+// convertDiaryMember converts an API diary member to its client representation
+func convertDiaryMember(apiMember *openapi.DiaryMember) *DiaryMember {
+	return &DiaryMember{
+		AccountID:           apiMember.AccountId,
+		Role:                DiaryRole(apiMember.Role),
+		EncryptionPublicKey: apiMember.EncryptionPublicKey,
+		SigningPublicKey:    apiMember.SignaturePublicKey,
+		CreatedAt:           apiMember.CreatedAt,
+	}
+}
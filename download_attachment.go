@@ -0,0 +1,150 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// DownloadAttachment decrypts the referenced attachment into w.
+//
+// Every chunk is authenticated before it is written, while the content hash and
+// size of the whole attachment can only be checked at the end. On ErrAttachmentCorrupted
+// discard whatever was written to w.
+func (c *Client) DownloadAttachment(ctx context.Context, diaryID string, ref AttachmentRef, w io.Writer) error {
+	if c.credentials == nil {
+		return ErrUnauthorized
+	}
+
+	// Get encryption keys
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get diary keys")
+	}
+
+	attachment, err := c.getAttachment(ctx, diaryID, ref.ID)
+	if err != nil {
+		return err
+	}
+
+	if !attachment.Completed {
+		return errors.Errorf("attachment %s upload is not completed", ref.ID)
+	}
+
+	attachmentKey, err := decryptAttachmentKey(attachment, keyring)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newGCM(attachmentKey)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	var size int64
+	for index := 0; index < attachment.ChunkCount; index++ {
+		sealed, err := c.downloadAttachmentChunk(ctx, diaryID, attachment.Id, index)
+		if err != nil {
+			return errors.Wrapf(err, "failed to download chunk %d", index)
+		}
+
+		// Only the last chunk opens with the final flag, which detects truncation
+		chunk, err := openAttachmentChunk(aead, attachment, index, index == attachment.ChunkCount-1, sealed)
+		if err != nil {
+			return err
+		}
+
+		hash.Write(chunk)
+		size += int64(len(chunk))
+
+		if _, err := w.Write(chunk); err != nil {
+			return errors.Wrap(err, "failed to write attachment")
+		}
+	}
+
+	if size != ref.Size || !bytes.Equal(hash.Sum(nil), ref.ContentHash) {
+		return ErrAttachmentCorrupted
+	}
+
+	return nil
+}
+
+func (c *Client) getAttachment(ctx context.Context, diaryID, attachmentID string) (*openapi.Attachment, error) {
+	url := fmt.Sprintf("%s/v1/diaries/%s/attachments/%s", c.baseURL, diaryID, attachmentID)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrAttachmentNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	var apiResponse openapi.GetAttachmentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response")
+	}
+
+	if err := validateAttachment(&apiResponse.Attachment); err != nil {
+		return nil, err
+	}
+
+	return &apiResponse.Attachment, nil
+}
+
+func (c *Client) downloadAttachmentChunk(ctx context.Context, diaryID, attachmentID string, index int) ([]byte, error) {
+	url := fmt.Sprintf("%s/v1/diaries/%s/attachments/%s/chunks/%d", c.baseURL, diaryID, attachmentID, index)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrAttachmentNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	// A sealed chunk is at most the maximum chunk size plus the GCM tag
+	sealed, err := io.ReadAll(io.LimitReader(resp.Body, openapi.MaxAttachmentChunkSize+64))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read chunk")
+	}
+
+	return sealed, nil
+}
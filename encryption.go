@@ -1,10 +1,13 @@
 package client
 
 import (
+	"bufio"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/binary"
 	"io"
+	"math"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/nacl/box"
@@ -20,20 +23,30 @@ func generateSymmetricKey() ([]byte, error) {
 	return key, nil
 }
 
+// newGCM creates an AES-256-GCM cipher
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AES cipher")
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GCM")
+	}
+
+	return gcm, nil
+}
+
 // encryptWithSymmetricKey encrypts data using AES-256-GCM
 func encryptWithSymmetricKey(data []byte, key []byte) (nonce []byte, ciphertext []byte, err error) {
 	if len(key) != 32 {
 		return nil, nil, errors.New("key must be 32 bytes for AES-256")
 	}
 
-	block, err := aes.NewCipher(key)
+	gcm, err := newGCM(key)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to create AES cipher")
-	}
-
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to create GCM")
+		return nil, nil, err
 	}
 
 	nonce = make([]byte, gcm.NonceSize())
@@ -51,14 +64,9 @@ func decryptWithSymmetricKey(nonce []byte, ciphertext []byte, key []byte) ([]byt
 		return nil, errors.New("key must be 32 bytes for AES-256")
 	}
 
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create AES cipher")
-	}
-
-	gcm, err := cipher.NewGCM(block)
+	gcm, err := newGCM(key)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create GCM")
+		return nil, err
 	}
 
 	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
@@ -107,3 +115,270 @@ func decryptWithPrivateKey(encrypted []byte, privateKey []byte, publicKey []byte
 
 	return decrypted, nil
 }
+
+// Streaming encryption uses the STREAM construction: the plaintext is split into
+// chunks sealed with AES-256-GCM under nonces made of a random per-stream prefix,
+// a big-endian chunk counter and a flag set only on the last chunk. Reordered,
+// duplicated or dropped chunks fail authentication, and so does a stream cut at a
+// chunk boundary, because its last chunk was not sealed as final.
+//
+// NewStreamWriter and NewStreamReader produce and read a self-contained stream
+// that starts with a header of a version byte and the nonce prefix. Attachments
+// store every sealed chunk separately instead, so interrupted uploads can resume.
+const (
+	// streamVersion is the first byte of every encrypted stream
+	streamVersion = 1
+
+	// streamChunkSize is the plaintext size of every chunk but the last one
+	streamChunkSize = 64 * 1024
+
+	// streamNoncePrefixSize leaves 5 bytes of the 12-byte GCM nonce for the counter and flag
+	streamNoncePrefixSize = 7
+)
+
+// streamNonce builds the nonce of chunk index
+func streamNonce(prefix []byte, index uint32, final bool) []byte {
+	nonce := make([]byte, 0, streamNoncePrefixSize+5)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, index)
+
+	if final {
+		return append(nonce, 1)
+	}
+
+	return append(nonce, 0)
+}
+
+// sealStreamChunk encrypts chunk index of a stream
+func sealStreamChunk(aead cipher.AEAD, prefix []byte, index int, final bool, chunk, additionalData []byte) ([]byte, error) {
+	if index < 0 || uint64(index) > math.MaxUint32 {
+		return nil, errors.New("stream has too many chunks")
+	}
+
+	// GCM panics on a nonce of the wrong size
+	if len(prefix) != streamNoncePrefixSize {
+		return nil, errors.Errorf("nonce prefix must be %d bytes", streamNoncePrefixSize)
+	}
+
+	return aead.Seal(nil, streamNonce(prefix, uint32(index), final), chunk, additionalData), nil
+}
+
+// openStreamChunk decrypts chunk index of a stream
+func openStreamChunk(aead cipher.AEAD, prefix []byte, index int, final bool, sealed, additionalData []byte) ([]byte, error) {
+	if index < 0 || uint64(index) > math.MaxUint32 {
+		return nil, errors.New("stream has too many chunks")
+	}
+
+	if len(prefix) != streamNoncePrefixSize {
+		return nil, errors.Errorf("nonce prefix must be %d bytes", streamNoncePrefixSize)
+	}
+
+	chunk, err := aead.Open(nil, streamNonce(prefix, uint32(index), final), sealed, additionalData)
+	if err != nil {
+		return nil, errors.Wrapf(ErrCorruptedStream, "chunk %d failed authentication", index)
+	}
+
+	return chunk, nil
+}
+
+// streamWriter encrypts everything written to it into the underlying writer
+type streamWriter struct {
+	w              io.Writer
+	aead           cipher.AEAD
+	prefix         []byte
+	additionalData []byte
+	buf            []byte
+	index          int
+	closed         bool
+}
+
+// NewStreamWriter returns a writer that encrypts into w with a 32-byte key.
+// additionalData is authenticated with every chunk and must be passed to
+// NewStreamReader unchanged. Close must be called to seal the final chunk; it does
+// not close w.
+func NewStreamWriter(w io.Writer, key, additionalData []byte) (io.WriteCloser, error) {
+	if len(key) != 32 {
+		return nil, errors.New("key must be 32 bytes for AES-256")
+	}
+
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, streamNoncePrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce prefix")
+	}
+
+	header := append([]byte{streamVersion}, prefix...)
+	if _, err := w.Write(header); err != nil {
+		return nil, errors.Wrap(err, "failed to write stream header")
+	}
+
+	sw := streamWriter{
+		w:              w,
+		aead:           aead,
+		prefix:         prefix,
+		additionalData: additionalData,
+		buf:            make([]byte, 0, streamChunkSize),
+	}
+
+	return &sw, nil
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if s.closed {
+		return 0, errors.New("write to closed stream")
+	}
+
+	written := 0
+	for len(p) > 0 {
+		// A full chunk is sealed only once more data arrives, as it may be the last one
+		if len(s.buf) == streamChunkSize {
+			if err := s.flush(false); err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(s.buf[len(s.buf):cap(s.buf)], p)
+		s.buf = s.buf[:len(s.buf)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+// Close seals the buffered data as the final chunk
+func (s *streamWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+
+	return s.flush(true)
+}
+
+func (s *streamWriter) flush(final bool) error {
+	sealed, err := sealStreamChunk(s.aead, s.prefix, s.index, final, s.buf, s.additionalData)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.w.Write(sealed); err != nil {
+		return errors.Wrap(err, "failed to write stream chunk")
+	}
+
+	s.index++
+	s.buf = s.buf[:0]
+
+	return nil
+}
+
+// streamReader decrypts a stream produced by NewStreamWriter
+type streamReader struct {
+	chunks         *chunkReader
+	aead           cipher.AEAD
+	prefix         []byte
+	additionalData []byte
+	plain          []byte
+	index          int
+	done           bool
+}
+
+// NewStreamReader returns a reader that decrypts a stream written by
+// NewStreamWriter. Data is returned only after its chunk was authenticated;
+// ErrCorruptedStream is returned on tampering or truncation.
+func NewStreamReader(r io.Reader, key, additionalData []byte) (io.Reader, error) {
+	if len(key) != 32 {
+		return nil, errors.New("key must be 32 bytes for AES-256")
+	}
+
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 1+streamNoncePrefixSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errors.Wrap(ErrCorruptedStream, "failed to read stream header")
+	}
+
+	if header[0] != streamVersion {
+		return nil, errors.Errorf("unsupported stream version: %d", header[0])
+	}
+
+	sr := streamReader{
+		chunks:         newChunkReader(r, streamChunkSize+aead.Overhead()),
+		aead:           aead,
+		prefix:         header[1:],
+		additionalData: additionalData,
+	}
+
+	return &sr, nil
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	for len(s.plain) == 0 {
+		if s.done {
+			return 0, io.EOF
+		}
+
+		sealed, final, err := s.chunks.next()
+		if err != nil {
+			return 0, err
+		}
+
+		s.plain, err = openStreamChunk(s.aead, s.prefix, s.index, final, sealed, s.additionalData)
+		if err != nil {
+			return 0, err
+		}
+
+		s.index++
+		s.done = final
+	}
+
+	n := copy(p, s.plain)
+	s.plain = s.plain[n:]
+
+	return n, nil
+}
+
+// chunkReader splits a stream into fixed size chunks and reports which chunk is the last one
+type chunkReader struct {
+	r   *bufio.Reader
+	buf []byte
+}
+
+func newChunkReader(r io.Reader, chunkSize int) *chunkReader {
+	return &chunkReader{
+		r:   bufio.NewReader(r),
+		buf: make([]byte, chunkSize),
+	}
+}
+
+// next returns the next chunk and whether it is the final one. An empty stream
+// yields a single empty final chunk. The returned slice is reused by the next call.
+func (r *chunkReader) next() ([]byte, bool, error) {
+	n, err := io.ReadFull(r.r, r.buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, false, errors.Wrap(err, "failed to read chunk")
+	}
+
+	if n < len(r.buf) {
+		return r.buf[:n], true, nil
+	}
+
+	// A full chunk is only final when nothing follows it
+	if _, err := r.r.Peek(1); err != nil {
+		if err == io.EOF {
+			return r.buf, true, nil
+		}
+
+		return nil, false, errors.Wrap(err, "failed to read chunk")
+	}
+
+	return r.buf, false, nil
+}
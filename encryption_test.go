@@ -0,0 +1,308 @@
+package client
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkReader(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		chunks []string
+	}{
+		{name: "empty", input: "", chunks: []string{""}},
+		{name: "shorter than chunk", input: "ab", chunks: []string{"ab"}},
+		{name: "exact chunk", input: "abcd", chunks: []string{"abcd"}},
+		{name: "multiple chunks", input: "abcdefghij", chunks: []string{"abcd", "efgh", "ij"}},
+		{name: "multiple exact chunks", input: "abcdefgh", chunks: []string{"abcd", "efgh"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := newChunkReader(strings.NewReader(tc.input), 4)
+
+			var chunks []string
+			for final := false; !final; {
+				chunk, isFinal, err := reader.next()
+				require.NoError(t, err)
+
+				chunks = append(chunks, string(chunk))
+				final = isFinal
+			}
+
+			assert.Equal(t, tc.chunks, chunks)
+		})
+	}
+}
+
+// sealStream splits plaintext into chunks of chunkSize and seals them in order
+func sealStream(t *testing.T, aead cipher.AEAD, prefix, plaintext, additionalData []byte, chunkSize int) [][]byte {
+	t.Helper()
+
+	var sealed [][]byte
+	reader := newChunkReader(bytes.NewReader(plaintext), chunkSize)
+	for index, final := 0, false; !final; index++ {
+		var chunk []byte
+		var err error
+		chunk, final, err = reader.next()
+		require.NoError(t, err)
+
+		s, err := sealStreamChunk(aead, prefix, index, final, chunk, additionalData)
+		require.NoError(t, err)
+		sealed = append(sealed, s)
+	}
+
+	return sealed
+}
+
+// openStream opens sealed chunks in order, treating the last one as final
+func openStream(aead cipher.AEAD, prefix []byte, sealed [][]byte, additionalData []byte) ([]byte, error) {
+	var plaintext []byte
+	for index, s := range sealed {
+		chunk, err := openStreamChunk(aead, prefix, index, index == len(sealed)-1, s, additionalData)
+		if err != nil {
+			return nil, err
+		}
+
+		plaintext = append(plaintext, chunk...)
+	}
+
+	return plaintext, nil
+}
+
+func TestStreamChunk_RoundTrip(t *testing.T) {
+	key, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	aead, err := newGCM(key)
+	require.NoError(t, err)
+
+	prefix := make([]byte, streamNoncePrefixSize)
+	_, err = rand.Read(prefix)
+	require.NoError(t, err)
+
+	const chunkSize = 1024
+	sizes := []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3*chunkSize + 17}
+
+	for _, size := range sizes {
+		plaintext := make([]byte, size)
+		_, err := rand.Read(plaintext)
+		require.NoError(t, err)
+
+		sealed := sealStream(t, aead, prefix, plaintext, []byte("context"), chunkSize)
+
+		decrypted, err := openStream(aead, prefix, sealed, []byte("context"))
+		require.NoError(t, err, "size %d", size)
+		assert.Equal(t, len(plaintext), len(decrypted), "size %d", size)
+		assert.True(t, bytes.Equal(plaintext, decrypted), "size %d", size)
+	}
+}
+
+func TestStreamChunk_DetectsTampering(t *testing.T) {
+	key, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	aead, err := newGCM(key)
+	require.NoError(t, err)
+
+	otherAEAD, err := newGCM(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	prefix := make([]byte, streamNoncePrefixSize)
+	const chunkSize = 1024
+
+	sealed := sealStream(t, aead, prefix, bytes.Repeat([]byte("diary"), chunkSize), nil, chunkSize)
+	require.Len(t, sealed, 5)
+
+	testCases := []struct {
+		name           string
+		aead           cipher.AEAD
+		chunks         [][]byte
+		additionalData []byte
+	}{
+		{
+			name:   "truncated at chunk boundary",
+			aead:   aead,
+			chunks: sealed[:2],
+		},
+		{
+			name:   "truncated inside chunk",
+			aead:   aead,
+			chunks: append(append([][]byte{}, sealed[:4]...), sealed[4][:len(sealed[4])-10]),
+		},
+		{
+			name:   "reordered chunks",
+			aead:   aead,
+			chunks: append([][]byte{sealed[1], sealed[0]}, sealed[2:]...),
+		},
+		{
+			name:   "duplicated chunk",
+			aead:   aead,
+			chunks: append([][]byte{sealed[0], sealed[0]}, sealed[2:]...),
+		},
+		{
+			name:   "appended chunk",
+			aead:   aead,
+			chunks: append(append([][]byte{}, sealed...), sealed[0]),
+		},
+		{
+			name:           "different additional data",
+			aead:           aead,
+			chunks:         sealed,
+			additionalData: []byte("other"),
+		},
+		{
+			name:   "wrong key",
+			aead:   otherAEAD,
+			chunks: sealed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := openStream(tc.aead, prefix, tc.chunks, tc.additionalData)
+			assert.ErrorIs(t, err, ErrCorruptedStream)
+		})
+	}
+}
+
+// encryptStream encrypts plaintext with a stream writer using small writes
+func encryptStream(t *testing.T, plaintext, key, additionalData []byte) []byte {
+	t.Helper()
+
+	var encrypted bytes.Buffer
+	w, err := NewStreamWriter(&encrypted, key, additionalData)
+	require.NoError(t, err)
+
+	for len(plaintext) > 0 {
+		n := min(len(plaintext), 1000)
+		_, err := w.Write(plaintext[:n])
+		require.NoError(t, err)
+		plaintext = plaintext[n:]
+	}
+
+	require.NoError(t, w.Close())
+
+	return encrypted.Bytes()
+}
+
+func TestStream_RoundTrip(t *testing.T) {
+	key, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	sizes := []int{0, 1, streamChunkSize - 1, streamChunkSize, streamChunkSize + 1, 3*streamChunkSize + 17}
+
+	for _, size := range sizes {
+		plaintext := make([]byte, size)
+		_, err := rand.Read(plaintext)
+		require.NoError(t, err)
+
+		encrypted := encryptStream(t, plaintext, key, []byte("context"))
+
+		r, err := NewStreamReader(bytes.NewReader(encrypted), key, []byte("context"))
+		require.NoError(t, err)
+
+		decrypted, err := io.ReadAll(r)
+		require.NoError(t, err, "size %d", size)
+		assert.True(t, bytes.Equal(plaintext, decrypted), "size %d", size)
+	}
+}
+
+func TestStream_DetectsTampering(t *testing.T) {
+	key, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	plaintext := bytes.Repeat([]byte("diary"), streamChunkSize)
+	encrypted := encryptStream(t, plaintext, key, nil)
+
+	headerSize := 1 + streamNoncePrefixSize
+	sealedChunkSize := streamChunkSize + 16
+	chunk := func(i int) []byte {
+		start := headerSize + i*sealedChunkSize
+		return encrypted[start:min(start+sealedChunkSize, len(encrypted))]
+	}
+	header := encrypted[:headerSize]
+
+	testCases := []struct {
+		name           string
+		stream         []byte
+		key            []byte
+		additionalData []byte
+	}{
+		{
+			name:   "truncated at chunk boundary",
+			stream: encrypted[:headerSize+2*sealedChunkSize],
+			key:    key,
+		},
+		{
+			name:   "truncated inside chunk",
+			stream: encrypted[:len(encrypted)-10],
+			key:    key,
+		},
+		{
+			name:   "only header",
+			stream: header,
+			key:    key,
+		},
+		{
+			name:   "reordered chunks",
+			stream: bytes.Join([][]byte{header, chunk(1), chunk(0), chunk(2), chunk(3), chunk(4)}, nil),
+			key:    key,
+		},
+		{
+			name:   "dropped chunk",
+			stream: bytes.Join([][]byte{header, chunk(0), chunk(2), chunk(3), chunk(4)}, nil),
+			key:    key,
+		},
+		{
+			name:   "duplicated chunk",
+			stream: bytes.Join([][]byte{header, chunk(0), chunk(0), chunk(2), chunk(3), chunk(4)}, nil),
+			key:    key,
+		},
+		{
+			name:   "appended data",
+			stream: append(append([]byte{}, encrypted...), 0),
+			key:    key,
+		},
+		{
+			name:           "different additional data",
+			stream:         encrypted,
+			key:            key,
+			additionalData: []byte("other"),
+		},
+		{
+			name:   "wrong key",
+			stream: encrypted,
+			key:    bytes.Repeat([]byte{1}, 32),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewStreamReader(bytes.NewReader(tc.stream), tc.key, tc.additionalData)
+			require.NoError(t, err)
+
+			_, err = io.ReadAll(r)
+			assert.ErrorIs(t, err, ErrCorruptedStream)
+		})
+	}
+}
+
+func TestStream_InvalidHeader(t *testing.T) {
+	key, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	_, err = NewStreamReader(strings.NewReader("abc"), key, nil)
+	assert.ErrorIs(t, err, ErrCorruptedStream)
+
+	_, err = NewStreamReader(bytes.NewReader(make([]byte, 1+streamNoncePrefixSize)), key, nil)
+	assert.ErrorContains(t, err, "unsupported stream version")
+}
@@ -1,6 +1,9 @@
 package client
 
 import (
+	"maps"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/samber/mo"
@@ -15,10 +18,21 @@ type Entry struct {
 	Archived      bool
 	Bookmarked    bool
 	PreviewHidden bool
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	DeletedAt     mo.Option[time.Time]
-	Version       uint64
+	Attachments   []AttachmentRef
+	Tags          []string
+	Metadata      EntryMetadata
+
+	// AuthoredAt is when the entry was written, set when it differs from CreatedAt,
+	// e.g. for imported entries
+	AuthoredAt mo.Option[time.Time]
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt mo.Option[time.Time]
+	Version   uint64
+
+	// unknown holds details fields written by newer clients
+	unknown unknownFields
 }
 
 // EntryDetails represents the plaintext content structure for entry details
@@ -27,4 +41,78 @@ type EntryDetails struct {
 	Archived      bool   `json:"archived"`
 	Bookmarked    bool   `json:"bookmarked"`
 	PreviewHidden bool   `json:"preview_hidden"`
+
+	Attachments []AttachmentRef `json:"attachments,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Metadata    *EntryMetadata  `json:"metadata,omitempty"`
+
+	// AuthoredAt is kept in the encrypted details because the server stamps
+	// CreatedAt on upload
+	AuthoredAt *time.Time `json:"authored_at,omitempty"`
+
+	unknown unknownFields
+}
+
+func (d EntryDetails) MarshalJSON() ([]byte, error) {
+	type plain EntryDetails
+	return marshalWithUnknown(plain(d), d.unknown)
+}
+
+func (d *EntryDetails) UnmarshalJSON(data []byte) error {
+	type plain EntryDetails
+
+	var p plain
+	unknown, err := unmarshalWithUnknown(data, &p)
+	if err != nil {
+		return err
+	}
+
+	*d = EntryDetails(p)
+	d.unknown = unknown
+
+	return nil
+}
+
+// authoredAt returns AuthoredAt as an option
+func (d EntryDetails) authoredAt() mo.Option[time.Time] {
+	if d.AuthoredAt != nil {
+		return mo.Some(*d.AuthoredAt)
+	}
+
+	return mo.None[time.Time]()
+}
+
+// EffectiveDate returns when the entry was written: AuthoredAt when set, otherwise
+// the time the server first stored it
+func (e *Entry) EffectiveDate() time.Time {
+	return e.AuthoredAt.OrElse(e.CreatedAt)
+}
+
+// SortEntries orders entries by effective date, oldest first, and by ID for
+// entries written at the same time
+func SortEntries(entries []*Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		di, dj := entries[i].EffectiveDate(), entries[j].EffectiveDate()
+		if !di.Equal(dj) {
+			return di.Before(dj)
+		}
+
+		return entries[i].ID < entries[j].ID
+	})
+}
+
+// PutParams returns the parameters that store the entry unchanged with PutEntry
+func (e *Entry) PutParams() PutEntryParams {
+	return PutEntryParams{
+		Content:       e.Content,
+		TopicID:       e.TopicID,
+		Archived:      e.Archived,
+		Bookmarked:    e.Bookmarked,
+		PreviewHidden: e.PreviewHidden,
+		Attachments:   slices.Clone(e.Attachments),
+		Tags:          slices.Clone(e.Tags),
+		Metadata:      e.Metadata.clone(),
+		AuthoredAt:    e.AuthoredAt,
+		unknown:       maps.Clone(e.unknown),
+	}
 }
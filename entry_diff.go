@@ -0,0 +1,161 @@
+package client
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DiffOp is the kind of change a DiffChunk describes
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffInsert
+	DiffDelete
+)
+
+// DiffChunk is a run of text that is unchanged, inserted or deleted. Joining the
+// equal and deleted chunks yields the old text, the equal and inserted ones the new text.
+type DiffChunk struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffLines compares two texts line by line
+func DiffLines(oldText, newText string) []DiffChunk {
+	return diffTokens(splitLines(oldText), splitLines(newText))
+}
+
+// DiffWords compares two texts word by word, treating runs of whitespace as words
+func DiffWords(oldText, newText string) []DiffChunk {
+	return diffTokens(splitWords(oldText), splitWords(newText))
+}
+
+// DiffEntries compares the content of two entry revisions line by line
+func DiffEntries(oldEntry, newEntry *Entry) []DiffChunk {
+	return DiffLines(oldEntry.Content, newEntry.Content)
+}
+
+// splitLines splits text into lines, keeping line endings
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// splitWords splits text into alternating runs of whitespace and non-whitespace
+func splitWords(text string) []string {
+	var words []string
+	start := 0
+	inSpace := false
+	for i, r := range text {
+		if isSpace := unicode.IsSpace(r); i > start && isSpace != inSpace {
+			words = append(words, text[start:i])
+			start = i
+			inSpace = isSpace
+		} else if i == 0 {
+			inSpace = isSpace
+		}
+	}
+
+	if start < len(text) {
+		words = append(words, text[start:])
+	}
+
+	return words
+}
+
+// maxDiffCells bounds the longest common subsequence table of diffTokens. Larger
+// changes are reported as a deletion of the old tokens and an insertion of the new.
+const maxDiffCells = 4_000_000
+
+// diffTokens computes a minimal diff of two token sequences from their longest
+// common subsequence. Common prefixes and suffixes are trimmed first, which keeps
+// the quadratic table small for typical edits.
+func diffTokens(a, b []string) []DiffChunk {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var chunks []DiffChunk
+	appendChunk := func(op DiffOp, text string) {
+		if n := len(chunks); n > 0 && chunks[n-1].Op == op {
+			chunks[n-1].Text += text
+			return
+		}
+
+		chunks = append(chunks, DiffChunk{Op: op, Text: text})
+	}
+
+	for _, token := range a[:prefix] {
+		appendChunk(DiffEqual, token)
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	if (len(midA)+1)*(len(midB)+1) > maxDiffCells {
+		if len(midA) > 0 {
+			appendChunk(DiffDelete, strings.Join(midA, ""))
+		}
+		if len(midB) > 0 {
+			appendChunk(DiffInsert, strings.Join(midB, ""))
+		}
+
+		for _, token := range a[len(a)-suffix:] {
+			appendChunk(DiffEqual, token)
+		}
+
+		return chunks
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of midA[i:] and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			appendChunk(DiffEqual, midA[i])
+			i++
+			j++
+		case i < len(midA) && (j == len(midB) || lcs[i+1][j] >= lcs[i][j+1]):
+			appendChunk(DiffDelete, midA[i])
+			i++
+		default:
+			appendChunk(DiffInsert, midB[j])
+			j++
+		}
+	}
+
+	for _, token := range a[len(a)-suffix:] {
+		appendChunk(DiffEqual, token)
+	}
+
+	return chunks
+}
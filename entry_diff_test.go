@@ -0,0 +1,76 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	oldText := "one\ntwo\nthree\n"
+	newText := "one\n2\nthree\nfour\n"
+
+	chunks := DiffLines(oldText, newText)
+
+	assert.Equal(t, []DiffChunk{
+		{Op: DiffEqual, Text: "one\n"},
+		{Op: DiffDelete, Text: "two\n"},
+		{Op: DiffInsert, Text: "2\n"},
+		{Op: DiffEqual, Text: "three\n"},
+		{Op: DiffInsert, Text: "four\n"},
+	}, chunks)
+}
+
+func TestDiffWords(t *testing.T) {
+	chunks := DiffWords("the quick brown fox", "the slow brown fox jumps")
+
+	assert.Equal(t, []DiffChunk{
+		{Op: DiffEqual, Text: "the "},
+		{Op: DiffDelete, Text: "quick"},
+		{Op: DiffInsert, Text: "slow"},
+		{Op: DiffEqual, Text: " brown fox"},
+		{Op: DiffInsert, Text: " jumps"},
+	}, chunks)
+}
+
+func TestDiff_Reconstructs(t *testing.T) {
+	cases := []struct{ oldText, newText string }{
+		{"", "new"},
+		{"old", ""},
+		{"a b c", "c b a"},
+		{"héllo wörld", "hello wörld!"},
+		{"line\nno newline", "line\nno newline\n"},
+	}
+
+	for _, tc := range cases {
+		for _, diff := range []func(string, string) []DiffChunk{DiffLines, DiffWords} {
+			var oldText, newText strings.Builder
+			for _, chunk := range diff(tc.oldText, tc.newText) {
+				if chunk.Op != DiffInsert {
+					oldText.WriteString(chunk.Text)
+				}
+				if chunk.Op != DiffDelete {
+					newText.WriteString(chunk.Text)
+				}
+			}
+
+			assert.Equal(t, tc.oldText, oldText.String())
+			assert.Equal(t, tc.newText, newText.String())
+		}
+	}
+}
+
+func TestDiffLines_LargeChangeReplacesAll(t *testing.T) {
+	oldText := "header\n" + strings.Repeat("old\n", 3000) + "footer\n"
+	newText := "header\n" + strings.Repeat("new\n", 3000) + "footer\n"
+
+	chunks := DiffLines(oldText, newText)
+
+	assert.Equal(t, []DiffChunk{
+		{Op: DiffEqual, Text: "header\n"},
+		{Op: DiffDelete, Text: strings.Repeat("old\n", 3000)},
+		{Op: DiffInsert, Text: strings.Repeat("new\n", 3000)},
+		{Op: DiffEqual, Text: "footer\n"},
+	}, chunks)
+}
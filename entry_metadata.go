@@ -0,0 +1,304 @@
+package client
+
+import (
+	"encoding/json"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+)
+
+// EntryMetadataSchemaVersion is the metadata schema version written by this client
+const EntryMetadataSchemaVersion = 1
+
+// Mood score bounds
+const (
+	MinMoodScore = 1
+	MaxMoodScore = 5
+)
+
+// EntryMetadata holds structured data about an entry, stored in encrypted entry details.
+//
+// Keys this client does not know, e.g. written by a newer client, are kept and
+// written back unchanged, so updating an entry does not drop them.
+type EntryMetadata struct {
+	// SchemaVersion is the version of the schema the metadata was written with
+	SchemaVersion int
+
+	Mood     mo.Option[Mood]
+	Location mo.Option[Location]
+	Weather  mo.Option[Weather]
+	Activity mo.Option[Activity]
+
+	// Custom holds user-defined typed fields by name
+	Custom map[string]CustomField
+
+	// unknown holds keys of newer schema versions, preserved verbatim
+	unknown unknownFields
+}
+
+// Mood is a self-reported mood
+type Mood struct {
+	// Score ranges from MinMoodScore (worst) to MaxMoodScore (best)
+	Score int    `json:"score"`
+	Label string `json:"label,omitempty"`
+}
+
+// Location is where an entry was written
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+
+	// AccuracyMeters is the radius of uncertainty, zero when unknown
+	AccuracyMeters float64 `json:"accuracy_meters,omitempty"`
+	PlaceName      string  `json:"place_name,omitempty"`
+}
+
+// Weather describes the weather when an entry was written
+type Weather struct {
+	TemperatureCelsius float64 `json:"temperature_celsius"`
+	Condition          string  `json:"condition,omitempty"`
+	HumidityPercent    float64 `json:"humidity_percent,omitempty"`
+}
+
+// Activity describes what the author was doing
+type Activity struct {
+	Type            string `json:"type"`
+	DurationSeconds int64  `json:"duration_seconds,omitempty"`
+	Steps           int    `json:"steps,omitempty"`
+}
+
+// entryMetadataJSON is the serialized form of known metadata keys
+type entryMetadataJSON struct {
+	SchemaVersion int                    `json:"schema_version"`
+	Mood          *Mood                  `json:"mood,omitempty"`
+	Location      *Location              `json:"location,omitempty"`
+	Weather       *Weather               `json:"weather,omitempty"`
+	Activity      *Activity              `json:"activity,omitempty"`
+	Custom        map[string]CustomField `json:"custom,omitempty"`
+}
+
+// IsZero reports whether the metadata holds no data
+func (m EntryMetadata) IsZero() bool {
+	return m.Mood.IsAbsent() &&
+		m.Location.IsAbsent() &&
+		m.Weather.IsAbsent() &&
+		m.Activity.IsAbsent() &&
+		len(m.Custom) == 0 &&
+		len(m.unknown) == 0
+}
+
+// Validate checks the known metadata fields
+func (m EntryMetadata) Validate() error {
+	if mood, ok := m.Mood.Get(); ok && (mood.Score < MinMoodScore || mood.Score > MaxMoodScore) {
+		return errors.Errorf("mood score %d is out of range [%d, %d]", mood.Score, MinMoodScore, MaxMoodScore)
+	}
+
+	if location, ok := m.Location.Get(); ok {
+		if location.Latitude < -90 || location.Latitude > 90 {
+			return errors.Errorf("latitude %f is out of range", location.Latitude)
+		}
+
+		if location.Longitude < -180 || location.Longitude > 180 {
+			return errors.Errorf("longitude %f is out of range", location.Longitude)
+		}
+	}
+
+	if activity, ok := m.Activity.Get(); ok && strings.TrimSpace(activity.Type) == "" {
+		return errors.New("activity type must not be empty")
+	}
+
+	for name := range m.Custom {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("custom field name must not be empty")
+		}
+	}
+
+	return nil
+}
+
+// clone returns a copy that does not share maps with m
+func (m EntryMetadata) clone() EntryMetadata {
+	m.Custom = maps.Clone(m.Custom)
+	m.unknown = maps.Clone(m.unknown)
+
+	return m
+}
+
+func (m EntryMetadata) MarshalJSON() ([]byte, error) {
+	data := entryMetadataJSON{
+		// Never downgrade the version of metadata written by a newer client
+		SchemaVersion: max(m.SchemaVersion, EntryMetadataSchemaVersion),
+		Mood:          optionPtr(m.Mood),
+		Location:      optionPtr(m.Location),
+		Weather:       optionPtr(m.Weather),
+		Activity:      optionPtr(m.Activity),
+		Custom:        m.Custom,
+	}
+
+	return marshalWithUnknown(data, m.unknown)
+}
+
+func (m *EntryMetadata) UnmarshalJSON(b []byte) error {
+	var data entryMetadataJSON
+	unknown, err := unmarshalWithUnknown(b, &data)
+	if err != nil {
+		return err
+	}
+
+	*m = EntryMetadata{
+		SchemaVersion: data.SchemaVersion,
+		Mood:          mo.PointerToOption(data.Mood),
+		Location:      mo.PointerToOption(data.Location),
+		Weather:       mo.PointerToOption(data.Weather),
+		Activity:      mo.PointerToOption(data.Activity),
+		Custom:        data.Custom,
+		unknown:       unknown,
+	}
+
+	return nil
+}
+
+// optionPtr converts an option into a pointer for omitempty serialization
+func optionPtr[T any](o mo.Option[T]) *T {
+	if value, ok := o.Get(); ok {
+		return &value
+	}
+
+	return nil
+}
+
+// CustomFieldType is the type of a custom metadata field value
+type CustomFieldType string
+
+const (
+	CustomFieldText   CustomFieldType = "text"
+	CustomFieldNumber CustomFieldType = "number"
+	CustomFieldBool   CustomFieldType = "bool"
+	CustomFieldTime   CustomFieldType = "time"
+)
+
+// CustomField is a typed user-defined metadata value. Values of types unknown to
+// this client are preserved as is.
+type CustomField struct {
+	Type  CustomFieldType
+	value json.RawMessage
+}
+
+// TextField creates a text custom field
+func TextField(value string) CustomField {
+	return newCustomField(CustomFieldText, value)
+}
+
+// NumberField creates a number custom field
+func NumberField(value float64) CustomField {
+	return newCustomField(CustomFieldNumber, value)
+}
+
+// BoolField creates a boolean custom field
+func BoolField(value bool) CustomField {
+	return newCustomField(CustomFieldBool, value)
+}
+
+// TimeField creates a timestamp custom field
+func TimeField(value time.Time) CustomField {
+	return newCustomField(CustomFieldTime, value.UTC())
+}
+
+func newCustomField(fieldType CustomFieldType, value any) CustomField {
+	// Marshaling strings, finite numbers, booleans and times cannot fail
+	raw, _ := json.Marshal(value)
+
+	return CustomField{Type: fieldType, value: raw}
+}
+
+// Text returns the value of a text field
+func (f CustomField) Text() (string, bool) {
+	return customFieldValue[string](f, CustomFieldText)
+}
+
+// Number returns the value of a number field
+func (f CustomField) Number() (float64, bool) {
+	return customFieldValue[float64](f, CustomFieldNumber)
+}
+
+// Bool returns the value of a boolean field
+func (f CustomField) Bool() (bool, bool) {
+	return customFieldValue[bool](f, CustomFieldBool)
+}
+
+// Time returns the value of a timestamp field
+func (f CustomField) Time() (time.Time, bool) {
+	return customFieldValue[time.Time](f, CustomFieldTime)
+}
+
+func customFieldValue[T any](f CustomField, fieldType CustomFieldType) (T, bool) {
+	var value T
+	if f.Type != fieldType {
+		return value, false
+	}
+
+	if err := json.Unmarshal(f.value, &value); err != nil {
+		return value, false
+	}
+
+	return value, true
+}
+
+// customFieldJSON is the serialized form of a custom field
+type customFieldJSON struct {
+	Type  CustomFieldType `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+func (f CustomField) MarshalJSON() ([]byte, error) {
+	value := f.value
+	if value == nil {
+		value = json.RawMessage("null")
+	}
+
+	return json.Marshal(customFieldJSON{Type: f.Type, Value: value})
+}
+
+func (f *CustomField) UnmarshalJSON(b []byte) error {
+	var data customFieldJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	*f = CustomField{Type: data.Type, value: data.Value}
+
+	return nil
+}
+
+// Mood returns the mood recorded with the entry
+func (e *Entry) Mood() mo.Option[Mood] {
+	return e.Metadata.Mood
+}
+
+// Location returns where the entry was written
+func (e *Entry) Location() mo.Option[Location] {
+	return e.Metadata.Location
+}
+
+// Weather returns the weather recorded with the entry
+func (e *Entry) Weather() mo.Option[Weather] {
+	return e.Metadata.Weather
+}
+
+// Activity returns the activity recorded with the entry
+func (e *Entry) Activity() mo.Option[Activity] {
+	return e.Metadata.Activity
+}
+
+// CustomField returns the custom metadata field with the given name
+func (e *Entry) CustomField(name string) mo.Option[CustomField] {
+	field, ok := e.Metadata.Custom[name]
+	if !ok {
+		return mo.None[CustomField]()
+	}
+
+	return mo.Some(field)
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryMetadata_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2021, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	metadata := EntryMetadata{
+		Mood:     mo.Some(Mood{Score: 4, Label: "calm"}),
+		Location: mo.Some(Location{Latitude: 38.72, Longitude: -9.14, PlaceName: "Lisbon"}),
+		Weather:  mo.Some(Weather{TemperatureCelsius: 21.5, Condition: "sunny"}),
+		Activity: mo.Some(Activity{Type: "walking", Steps: 8000}),
+		Custom: map[string]CustomField{
+			"coffee":   NumberField(2),
+			"gym":      BoolField(true),
+			"book":     TextField("Dune"),
+			"imported": TimeField(createdAt),
+		},
+	}
+
+	data, err := json.Marshal(metadata)
+	require.NoError(t, err)
+
+	var decoded EntryMetadata
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, EntryMetadataSchemaVersion, decoded.SchemaVersion)
+	assert.Equal(t, metadata.Mood, decoded.Mood)
+	assert.Equal(t, metadata.Location, decoded.Location)
+	assert.Equal(t, metadata.Weather, decoded.Weather)
+	assert.Equal(t, metadata.Activity, decoded.Activity)
+
+	coffee, ok := decoded.Custom["coffee"].Number()
+	assert.True(t, ok)
+	assert.Equal(t, 2.0, coffee)
+
+	gym, ok := decoded.Custom["gym"].Bool()
+	assert.True(t, ok)
+	assert.True(t, gym)
+
+	book, ok := decoded.Custom["book"].Text()
+	assert.True(t, ok)
+	assert.Equal(t, "Dune", book)
+
+	imported, ok := decoded.Custom["imported"].Time()
+	assert.True(t, ok)
+	assert.True(t, createdAt.Equal(imported))
+
+	_, ok = decoded.Custom["book"].Number()
+	assert.False(t, ok, "typed accessor must reject other types")
+}
+
+func TestEntryMetadata_PreservesUnknownFields(t *testing.T) {
+	// Written by a newer client with a schema this client does not know
+	input := `{
+		"schema_version": 3,
+		"mood": {"score": 2, "intensity": 0.7},
+		"sleep": {"hours": 7.5},
+		"custom": {"rating": {"type": "stars", "value": 4}}
+	}`
+
+	var metadata EntryMetadata
+	require.NoError(t, json.Unmarshal([]byte(input), &metadata))
+
+	assert.Equal(t, 3, metadata.SchemaVersion)
+	assert.Equal(t, 2, metadata.Mood.MustGet().Score)
+	assert.False(t, metadata.IsZero())
+
+	// Update a known field and write the metadata back
+	metadata.Weather = mo.Some(Weather{TemperatureCelsius: 10})
+
+	data, err := json.Marshal(metadata)
+	require.NoError(t, err)
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &fields))
+
+	assert.JSONEq(t, `3`, string(fields["schema_version"]))
+	assert.JSONEq(t, `{"hours": 7.5}`, string(fields["sleep"]))
+	assert.JSONEq(t, `{"rating": {"type": "stars", "value": 4}}`, string(fields["custom"]))
+	assert.JSONEq(t, `{"temperature_celsius": 10}`, string(fields["weather"]))
+}
+
+func TestEntryMetadata_IsZero(t *testing.T) {
+	assert.True(t, EntryMetadata{}.IsZero())
+	assert.False(t, EntryMetadata{Mood: mo.Some(Mood{Score: 3})}.IsZero())
+	assert.False(t, EntryMetadata{Custom: map[string]CustomField{"a": BoolField(false)}}.IsZero())
+}
+
+func TestEntryMetadata_Validate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		metadata    EntryMetadata
+		expectError bool
+	}{
+		{name: "empty", metadata: EntryMetadata{}},
+		{name: "valid mood", metadata: EntryMetadata{Mood: mo.Some(Mood{Score: MaxMoodScore})}},
+		{name: "mood too low", metadata: EntryMetadata{Mood: mo.Some(Mood{Score: 0})}, expectError: true},
+		{name: "mood too high", metadata: EntryMetadata{Mood: mo.Some(Mood{Score: 6})}, expectError: true},
+		{name: "invalid latitude", metadata: EntryMetadata{Location: mo.Some(Location{Latitude: 91})}, expectError: true},
+		{name: "invalid longitude", metadata: EntryMetadata{Location: mo.Some(Location{Longitude: -181})}, expectError: true},
+		{name: "empty activity type", metadata: EntryMetadata{Activity: mo.Some(Activity{Steps: 10})}, expectError: true},
+		{name: "empty custom field name", metadata: EntryMetadata{Custom: map[string]CustomField{" ": TextField("x")}}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.metadata.Validate()
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func (s *ClientSuite) TestEntry_PutEntry_Metadata() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-entry-metadata-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	// Act
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{
+		Content: "Morning run",
+		Metadata: EntryMetadata{
+			Mood:     mo.Some(Mood{Score: 5}),
+			Activity: mo.Some(Activity{Type: "running", DurationSeconds: 1800}),
+			Custom:   map[string]CustomField{"distance_km": NumberField(5.2)},
+		},
+	})
+	require.NoError(t, err)
+
+	gotEntry, err := s.client.GetEntryByID(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 5, gotEntry.Mood().MustGet().Score)
+	assert.Equal(t, "running", gotEntry.Activity().MustGet().Type)
+	assert.True(t, gotEntry.Location().IsAbsent())
+
+	distance, ok := gotEntry.CustomField("distance_km").MustGet().Number()
+	assert.True(t, ok)
+	assert.Equal(t, 5.2, distance)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// normalizeTags trims tags, drops empty ones and removes case-insensitive
+// duplicates, keeping the first spelling and the original order
+func normalizeTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || containsTag(normalized, tag) {
+			continue
+		}
+
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+// containsTag reports whether tags contains tag, compared case-insensitively
+func containsTag(tags []string, tag string) bool {
+	tag = strings.TrimSpace(tag)
+
+	return slices.ContainsFunc(tags, func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
+}
+
+// HasTag reports whether the entry carries tag, compared case-insensitively
+func (e *Entry) HasTag(tag string) bool {
+	return containsTag(e.Tags, tag)
+}
+
+// AddTags adds tags to an entry. Tags the entry already carries are ignored.
+func (c *Client) AddTags(ctx context.Context, diaryID, entryID string, tags ...string) (*Entry, error) {
+	entry, err := c.GetEntryByID(ctx, diaryID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := entry.PutParams()
+	params.Tags = normalizeTags(append(params.Tags, tags...))
+
+	return c.PutEntry(ctx, diaryID, entryID, params)
+}
+
+// RemoveTags removes tags from an entry. Tags the entry does not carry are ignored.
+func (c *Client) RemoveTags(ctx context.Context, diaryID, entryID string, tags ...string) (*Entry, error) {
+	entry, err := c.GetEntryByID(ctx, diaryID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := entry.PutParams()
+	params.Tags = slices.DeleteFunc(params.Tags, func(tag string) bool {
+		return containsTag(tags, tag)
+	})
+
+	return c.PutEntry(ctx, diaryID, entryID, params)
+}
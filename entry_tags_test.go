@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tags     []string
+		expected []string
+	}{
+		{name: "nil", tags: nil, expected: nil},
+		{name: "trims whitespace", tags: []string{" work ", "travel"}, expected: []string{"work", "travel"}},
+		{name: "drops empty", tags: []string{"", "  ", "work"}, expected: []string{"work"}},
+		{name: "removes duplicates", tags: []string{"Work", "work", "WORK"}, expected: []string{"Work"}},
+		{name: "keeps order", tags: []string{"b", "a", "c"}, expected: []string{"b", "a", "c"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, normalizeTags(tc.tags))
+		})
+	}
+}
+
+func TestEntry_HasTag(t *testing.T) {
+	entry := Entry{Tags: []string{"Travel", "work"}}
+
+	assert.True(t, entry.HasTag("travel"))
+	assert.True(t, entry.HasTag(" WORK "))
+	assert.False(t, entry.HasTag("family"))
+}
+
+func (s *ClientSuite) TestEntry_AddRemoveTags() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-entry-tags-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{
+		Content:    "Flight to Lisbon",
+		Bookmarked: true,
+		Tags:       []string{"travel"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"travel"}, entry.Tags)
+
+	// Act: Add tags
+	updated, err := s.client.AddTags(ctx, diary.ID, entry.ID, "Portugal", "TRAVEL", "work")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"travel", "Portugal", "work"}, updated.Tags)
+	assert.Equal(t, "Flight to Lisbon", updated.Content)
+	assert.True(t, updated.Bookmarked)
+
+	// Act: Remove tags
+	updated, err = s.client.RemoveTags(ctx, diary.ID, entry.ID, "WORK", "missing")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"travel", "Portugal"}, updated.Tags)
+
+	gotEntry, err := s.client.GetEntryByID(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"travel", "Portugal"}, gotEntry.Tags)
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntry_EffectiveDate(t *testing.T) {
+	created := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	authored := time.Date(2019, 6, 1, 7, 15, 0, 0, time.UTC)
+
+	assert.Equal(t, created, (&Entry{CreatedAt: created}).EffectiveDate())
+	assert.Equal(t, authored, (&Entry{CreatedAt: created, AuthoredAt: mo.Some(authored)}).EffectiveDate())
+}
+
+func TestSortEntries(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	entries := []*Entry{
+		{ID: "imported", CreatedAt: day(10), AuthoredAt: mo.Some(day(1))},
+		{ID: "b", CreatedAt: day(5)},
+		{ID: "a", CreatedAt: day(5)},
+		{ID: "first", CreatedAt: day(3)},
+	}
+
+	SortEntries(entries)
+
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	assert.Equal(t, []string{"imported", "first", "a", "b"}, ids)
+}
+
+func TestEntryDetails_AuthoredAt(t *testing.T) {
+	authored := time.Date(2019, 6, 1, 9, 15, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	data, err := json.Marshal(PutEntryParams{Content: "Hi", AuthoredAt: mo.Some(authored)}.GetEntryDetails())
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"authored_at":"2019-06-01T07:15:00Z"`)
+
+	var details EntryDetails
+	require.NoError(t, json.Unmarshal(data, &details))
+	require.NotNil(t, details.AuthoredAt)
+	assert.True(t, authored.Equal(*details.AuthoredAt))
+
+	data, err = json.Marshal(PutEntryParams{Content: "Hi"}.GetEntryDetails())
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "authored_at")
+}
@@ -13,4 +13,22 @@ var (
 	ErrTopicNotFound        = errors.New("topic not found")
 	ErrTemplateNotFound     = errors.New("template not found")
 	ErrDiaryLimitExceeded   = errors.New("diary limit exceeded")
+	ErrAccountNotFound      = errors.New("account not found")
+	ErrMemberNotFound       = errors.New("member not found")
+	ErrMemberAlreadyExists  = errors.New("member already exists")
+	ErrKeyChanged           = errors.New("account keys changed since last verification")
+	ErrKeyRotationFailed    = errors.New("diary key rotation failed")
+	ErrInvalidPassphrase    = errors.New("invalid passphrase")
+	ErrDeviceNotFound       = errors.New("device not found")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrAttachmentCorrupted  = errors.New("attachment content does not match its reference")
+	ErrInvalidAttachment    = errors.New("attachment returned by the server is malformed")
+	ErrCorruptedStream      = errors.New("encrypted stream is corrupted or truncated")
+	ErrTopicCycle           = errors.New("topic hierarchy contains a cycle")
+	ErrAmbiguousTopicPath   = errors.New("topic path matches more than one topic")
+	ErrRevisionNotFound     = errors.New("entry revision not found")
+	ErrVersionConflict      = errors.New("a newer version is stored on the server")
+	ErrPartialMove          = errors.New("entry was copied but the source entry was not deleted")
+	ErrChangesDiscarded     = errors.New("queued changes were discarded")
+	ErrInvalidBackup        = errors.New("backup archive is invalid or was altered")
 )
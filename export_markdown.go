@@ -0,0 +1,319 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ExportMarkdown writes the diary into dir as plaintext Markdown: one file per entry
+// with YAML front matter, a folder per topic under topics/, entries without a topic
+// under entries/, templates under templates/ and an index.md linking everything.
+//
+// Entries are dated and ordered by their effective date. The output only depends
+// on the diary content, so repeated exports can be compared with diff. Markdown
+// files of an earlier export of the same diary that no longer match an entity, e.g.
+// after a rename or deletion, are removed; other files in dir are left alone.
+// Deleted entities and attachment content are not exported.
+func (c *Client) ExportMarkdown(ctx context.Context, diaryID, dir string) error {
+	diary, err := c.GetDiaryByID(ctx, diaryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get diary")
+	}
+
+	topics, err := c.GetTopics(ctx, diaryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get topics")
+	}
+
+	templates, err := c.GetTemplates(ctx, diaryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get templates")
+	}
+
+	entries, err := c.GetEntries(ctx, diaryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get entries")
+	}
+
+	files, err := renderMarkdownExport(diary, topics, templates, entries)
+	if err != nil {
+		return err
+	}
+
+	// Deleted entities are included, so their files from earlier exports are removed
+	ids := map[string]bool{}
+	for _, topic := range topics {
+		ids[topic.ID] = true
+	}
+	for _, template := range templates {
+		ids[template.ID] = true
+	}
+	for _, entry := range entries {
+		ids[entry.ID] = true
+	}
+
+	return writeMarkdownExport(dir, files, ids)
+}
+
+// renderMarkdownExport returns the files of a Markdown export by slash separated
+// path relative to the export directory
+func renderMarkdownExport(diary *Diary, topics []*Topic, templates []*Template, entries []*Entry) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	var index strings.Builder
+	fmt.Fprintf(&index, "# %s\n", diary.Title)
+	if diary.Description != "" {
+		fmt.Fprintf(&index, "\n%s\n", strings.TrimSpace(diary.Description))
+	}
+
+	tree, err := buildTopicTree(topics)
+	if err != nil {
+		return nil, err
+	}
+
+	// Topic folders and titles by topic ID, parents before children
+	folders := map[string]string{}
+	paths := map[string]string{}
+
+	var indexTopics strings.Builder
+	var renderTopics func(nodes []*TopicNode, dir, titlePath string, depth int) error
+	renderTopics = func(nodes []*TopicNode, dir, titlePath string, depth int) error {
+		used := map[string]bool{}
+		for _, node := range nodes {
+			topic := node.Topic
+
+			name := slugify(topic.Title)
+			if name == "" || used[name] {
+				name = strings.Trim(name+"-"+shortID(topic.ID), "-")
+			}
+			used[name] = true
+
+			folder := path.Join(dir, name)
+			folders[topic.ID] = folder
+			paths[topic.ID] = strings.TrimPrefix(titlePath+"/"+topic.Title, "/")
+
+			data, err := encodeMarkdown(markdownTopic{
+				ID:              topic.ID,
+				Title:           topic.Title,
+				Color:           topic.Color,
+				DefaultTemplate: topic.DefaultTemplateID.OrEmpty(),
+			}, topic.Description)
+			if err != nil {
+				return errors.Wrapf(err, "failed to render topic %s", topic.ID)
+			}
+
+			file := path.Join(folder, markdownTopicFileName)
+			files[file] = data
+			fmt.Fprintf(&indexTopics, "%s- [%s](%s)\n", strings.Repeat("  ", depth), topic.Title, file)
+
+			if err := renderTopics(node.Children, folder, paths[topic.ID], depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := renderTopics(tree, markdownTopicsDir, "", 0); err != nil {
+		return nil, err
+	}
+
+	if indexTopics.Len() > 0 {
+		fmt.Fprintf(&index, "\n## Topics\n\n%s", indexTopics.String())
+	}
+
+	sortedTemplates := liveOnly(templates, func(t *Template) bool { return t.DeletedAt.IsPresent() })
+	sort.Slice(sortedTemplates, func(i, j int) bool {
+		return sortedTemplates[i].ID < sortedTemplates[j].ID
+	})
+
+	var indexTemplates strings.Builder
+	for _, template := range sortedTemplates {
+		data, err := encodeMarkdown(markdownTemplate{
+			ID:          template.ID,
+			Name:        template.Name,
+			Description: template.Description,
+			Icon:        template.Icon,
+			Color:       template.Color,
+			Prompts:     template.Prompts,
+		}, template.Content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render template %s", template.ID)
+		}
+
+		file := path.Join(markdownTemplatesDir, markdownFileName("", template.Name, template.ID))
+		files[file] = data
+
+		name := template.Name
+		if name == "" {
+			name = template.ID
+		}
+		fmt.Fprintf(&indexTemplates, "- [%s](%s)\n", name, file)
+	}
+
+	if indexTemplates.Len() > 0 {
+		fmt.Fprintf(&index, "\n## Templates\n\n%s", indexTemplates.String())
+	}
+
+	sortedEntries := liveOnly(entries, func(e *Entry) bool { return e.DeletedAt.IsPresent() })
+	SortEntries(sortedEntries)
+
+	var indexEntries strings.Builder
+	for _, entry := range sortedEntries {
+		metadata, err := metadataToYAML(entry.Metadata)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render entry %s", entry.ID)
+		}
+
+		folder, topicPath := markdownEntriesDir, ""
+		if topicID, ok := entry.TopicID.Get(); ok && folders[topicID] != "" {
+			folder, topicPath = folders[topicID], paths[topicID]
+		}
+
+		var authored *time.Time
+		if authoredAt, ok := entry.AuthoredAt.Get(); ok {
+			authoredAt = authoredAt.UTC()
+			authored = &authoredAt
+		}
+
+		data, err := encodeMarkdown(markdownEntry{
+			ID:            entry.ID,
+			Created:       entry.CreatedAt.UTC(),
+			Updated:       entry.UpdatedAt.UTC(),
+			Authored:      authored,
+			Topic:         topicPath,
+			Bookmarked:    entry.Bookmarked,
+			Archived:      entry.Archived,
+			PreviewHidden: entry.PreviewHidden,
+			Tags:          entry.Tags,
+			Metadata:      metadata,
+			Attachments:   toMarkdownAttachments(entry.Attachments),
+		}, entry.Content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render entry %s", entry.ID)
+		}
+
+		date := entry.EffectiveDate().UTC().Format("2006-01-02")
+		title := entryTitle(entry.Content)
+
+		file := path.Join(folder, markdownFileName(date, title, entry.ID))
+		files[file] = data
+
+		if title == "" {
+			title = "Untitled"
+		}
+		fmt.Fprintf(&indexEntries, "- %s [%s](%s)\n", date, title, file)
+	}
+
+	if indexEntries.Len() > 0 {
+		fmt.Fprintf(&index, "\n## Entries\n\n%s", indexEntries.String())
+	}
+
+	files[markdownIndexName] = []byte(index.String())
+
+	return files, nil
+}
+
+// markdownFileName builds a file name from an optional date prefix, a title and
+// the ID that keeps it unique
+func markdownFileName(date, title, id string) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{date, slugify(title), shortID(id)} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, "-") + ".md"
+}
+
+// liveOnly returns the items that are not deleted
+func liveOnly[T any](items []*T, deleted func(*T) bool) []*T {
+	result := make([]*T, 0, len(items))
+	for _, item := range items {
+		if !deleted(item) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// writeMarkdownExport writes files into dir and removes Markdown files an earlier
+// export left behind in the folders the export owns. Only files whose front matter
+// names one of ids are removed, so files of other diaries or of the user are kept.
+func writeMarkdownExport(dir string, files map[string][]byte, ids map[string]bool) error {
+	for name, data := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return errors.Wrap(err, "failed to create export directory")
+		}
+
+		if err := writeFileAtomically(file, data); err != nil {
+			return errors.Wrapf(err, "failed to write %s", name)
+		}
+	}
+
+	for _, owned := range []string{markdownEntriesDir, markdownTopicsDir, markdownTemplatesDir} {
+		root := filepath.Join(dir, owned)
+
+		var dirs []string
+		err := filepath.WalkDir(root, func(file string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				dirs = append(dirs, file)
+				return nil
+			}
+
+			name, err := filepath.Rel(dir, file)
+			if err != nil {
+				return err
+			}
+
+			if _, ok := files[filepath.ToSlash(name)]; ok || !strings.HasSuffix(name, ".md") {
+				return nil
+			}
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+
+			var frontMatter struct {
+				ID string `yaml:"id"`
+			}
+			if _, hasFrontMatter, err := decodeMarkdown(data, &frontMatter); err != nil || !hasFrontMatter || !ids[frontMatter.ID] {
+				return nil
+			}
+
+			return os.Remove(file)
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to remove stale files")
+		}
+
+		// Deepest folders first, so emptied parents are removed as well
+		for i := len(dirs) - 1; i >= 0; i-- {
+			if entries, err := os.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+				os.Remove(dirs[i])
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	fingerprintVersion = 1
+
+	// safetyNumberIterations slows down brute-forcing keys with a chosen safety number
+	safetyNumberIterations = 5200
+)
+
+// Fingerprint is a short digest of a public key that users can compare out of band
+type Fingerprint [sha256.Size]byte
+
+// AccountKeys holds the public keys identifying an account
+type AccountKeys struct {
+	SigningPublicKey    []byte
+	EncryptionPublicKey []byte
+}
+
+// NewFingerprint computes the fingerprint of a public key
+func NewFingerprint(publicKey []byte) Fingerprint {
+	h := sha256.New()
+	h.Write([]byte("thingsdiary-fingerprint"))
+	h.Write([]byte{fingerprintVersion})
+	h.Write(publicKey)
+
+	var fingerprint Fingerprint
+	copy(fingerprint[:], h.Sum(nil))
+
+	return fingerprint
+}
+
+// Hex returns the first 20 bytes of the fingerprint as ten groups of four hex digits
+func (f Fingerprint) Hex() string {
+	encoded := strings.ToUpper(hex.EncodeToString(f[:20]))
+
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+
+	return strings.Join(groups, " ")
+}
+
+// Words returns the first 8 bytes of the fingerprint as a list of words
+func (f Fingerprint) Words() []string {
+	words := make([]string, 0, 8)
+	for _, b := range f[:8] {
+		words = append(words, fingerprintWords[b])
+	}
+
+	return words
+}
+
+// Emoji returns the first 48 bits of the fingerprint as a list of 8 emoji
+func (f Fingerprint) Emoji() []string {
+	var bits uint64
+	for _, b := range f[:6] {
+		bits = bits<<8 | uint64(b)
+	}
+
+	emoji := make([]string, 8)
+	for i := range emoji {
+		emoji[i] = fingerprintEmoji[(bits>>(42-6*i))&0x3f]
+	}
+
+	return emoji
+}
+
+// QRString returns the full fingerprint using only characters from the QR code
+// alphanumeric set, so it encodes compactly
+func (f Fingerprint) QRString() string {
+	return fmt.Sprintf("THINGSDIARY:%d:%s", fingerprintVersion, strings.ToUpper(hex.EncodeToString(f[:])))
+}
+
+// String returns the hex representation of the fingerprint
+func (f Fingerprint) String() string {
+	return f.Hex()
+}
+
+// SigningFingerprint returns the fingerprint of the signing public key
+func (c *Credentials) SigningFingerprint() Fingerprint {
+	return NewFingerprint(c.SigningPublicKey)
+}
+
+// EncryptionFingerprint returns the fingerprint of the encryption public key
+func (c *Credentials) EncryptionFingerprint() Fingerprint {
+	return NewFingerprint(c.EncryptionPublicKey)
+}
+
+// AccountKeys returns the public keys identifying the account
+func (c *Credentials) AccountKeys() AccountKeys {
+	return AccountKeys{
+		SigningPublicKey:    c.SigningPublicKey,
+		EncryptionPublicKey: c.EncryptionPublicKey,
+	}
+}
+
+// AccountKeys returns the public keys identifying the member
+func (m *DiaryMember) AccountKeys() AccountKeys {
+	return AccountKeys{
+		SigningPublicKey:    m.SigningPublicKey,
+		EncryptionPublicKey: m.EncryptionPublicKey,
+	}
+}
+
+// Equal reports whether both sets of keys are identical
+func (k AccountKeys) Equal(other AccountKeys) bool {
+	return bytes.Equal(k.SigningPublicKey, other.SigningPublicKey) &&
+		bytes.Equal(k.EncryptionPublicKey, other.EncryptionPublicKey)
+}
+
+// SafetyNumber computes a 60-digit number two users can compare to verify each
+// other's keys. The result does not depend on the order of the arguments.
+func SafetyNumber(a, b AccountKeys) string {
+	first := safetyNumberHalf(a)
+	second := safetyNumberHalf(b)
+	if first > second {
+		first, second = second, first
+	}
+
+	combined := first + second
+
+	groups := make([]string, 0, len(combined)/5)
+	for i := 0; i < len(combined); i += 5 {
+		groups = append(groups, combined[i:i+5])
+	}
+
+	return strings.Join(groups, " ")
+}
+
+// safetyNumberHalf derives the 30-digit half of a safety number contributed by one account
+func safetyNumberHalf(keys AccountKeys) string {
+	var version [2]byte
+	binary.BigEndian.PutUint16(version[:], fingerprintVersion)
+
+	digest := append(version[:], keys.SigningPublicKey...)
+	digest = append(digest, keys.EncryptionPublicKey...)
+	for i := 0; i < safetyNumberIterations; i++ {
+		h := sha512.New()
+		h.Write(digest)
+		h.Write(keys.SigningPublicKey)
+		h.Write(keys.EncryptionPublicKey)
+		digest = h.Sum(nil)
+	}
+
+	var sb strings.Builder
+	for i := 0; i < 30; i += 5 {
+		chunk := uint64(digest[i])<<32 | uint64(digest[i+1])<<24 | uint64(digest[i+2])<<16 |
+			uint64(digest[i+3])<<8 | uint64(digest[i+4])
+		fmt.Fprintf(&sb, "%05d", chunk%100000)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint_Formats(t *testing.T) {
+	creds, err := NewCredentials("fingerprint test seed phrase")
+	require.NoError(t, err)
+
+	fingerprint := creds.SigningFingerprint()
+
+	t.Run("hex groups", func(t *testing.T) {
+		groups := strings.Split(fingerprint.Hex(), " ")
+		require.Len(t, groups, 10)
+		for _, group := range groups {
+			assert.Regexp(t, "^[0-9A-F]{4}$", group)
+		}
+	})
+
+	t.Run("words", func(t *testing.T) {
+		words := fingerprint.Words()
+		require.Len(t, words, 8)
+		assert.Equal(t, fingerprintWords[fingerprint[0]], words[0])
+	})
+
+	t.Run("emoji", func(t *testing.T) {
+		assert.Len(t, fingerprint.Emoji(), 8)
+	})
+
+	t.Run("qr string uses alphanumeric set", func(t *testing.T) {
+		assert.Regexp(t, "^THINGSDIARY:1:[0-9A-F]{64}$", fingerprint.QRString())
+	})
+
+	t.Run("deterministic and key specific", func(t *testing.T) {
+		assert.Equal(t, fingerprint, NewFingerprint(creds.SigningPublicKey))
+		assert.NotEqual(t, fingerprint, creds.EncryptionFingerprint())
+	})
+}
+
+func TestSafetyNumber(t *testing.T) {
+	alice, err := NewCredentials("alice seed phrase")
+	require.NoError(t, err)
+
+	bob, err := NewCredentials("bob seed phrase")
+	require.NoError(t, err)
+
+	mallory, err := NewCredentials("mallory seed phrase")
+	require.NoError(t, err)
+
+	number := SafetyNumber(alice.AccountKeys(), bob.AccountKeys())
+
+	groups := strings.Split(number, " ")
+	require.Len(t, groups, 12)
+	for _, group := range groups {
+		assert.Regexp(t, "^[0-9]{5}$", group)
+	}
+
+	assert.Equal(t, number, SafetyNumber(bob.AccountKeys(), alice.AccountKeys()))
+	assert.NotEqual(t, number, SafetyNumber(alice.AccountKeys(), mallory.AccountKeys()))
+}
@@ -0,0 +1,49 @@
+package client
+
+// fingerprintWords maps each byte of a fingerprint to a word
+var fingerprintWords = [256]string{
+	"acid", "acorn", "actor", "adult", "agent", "alarm", "album", "alert",
+	"alley", "alpha", "amber", "anchor", "angle", "ankle", "apple", "apron",
+	"arena", "argue", "armor", "arrow", "atlas", "attic", "audio", "autumn",
+	"avocado", "axis", "bacon", "badge", "bagel", "baker", "bamboo", "banjo",
+	"barrel", "basil", "basket", "beach", "beacon", "beard", "beaver", "bench",
+	"berry", "bicycle", "bishop", "blanket", "blossom", "board", "bonus", "boots",
+	"bottle", "boxer", "branch", "bread", "brick", "bridge", "bronze", "brush",
+	"bubble", "bucket", "buffalo", "butter", "button", "cabin", "cactus", "camel",
+	"candle", "canoe", "canvas", "carbon", "carpet", "carrot", "castle", "cattle",
+	"cedar", "cello", "chalk", "cheese", "cherry", "chess", "chimney", "cider",
+	"cinema", "circle", "citrus", "clock", "cloud", "clover", "cobalt", "coconut",
+	"coffee", "comet", "compass", "copper", "coral", "cotton", "cousin", "coyote",
+	"crayon", "cricket", "crown", "crystal", "cubic", "cupboard", "curtain", "cushion",
+	"dagger", "daisy", "dancer", "delta", "denim", "desert", "diamond", "dinner",
+	"dolphin", "donkey", "dragon", "drum", "eagle", "easel", "echo", "elbow",
+	"elder", "ember", "emerald", "engine", "falcon", "feather", "fence", "fiddle",
+	"finch", "flag", "flute", "forest", "fossil", "fountain", "fox", "galaxy",
+	"garden", "garlic", "gecko", "geyser", "ginger", "giraffe", "glacier", "globe",
+	"goblet", "gorilla", "granite", "grape", "gravel", "guitar", "hammer", "harbor",
+	"harp", "hazel", "helmet", "heron", "hickory", "honey", "horizon", "hornet",
+	"husky", "igloo", "index", "iris", "island", "ivory", "jacket", "jaguar",
+	"jasmine", "jelly", "jigsaw", "jungle", "kayak", "kettle", "kiwi", "koala",
+	"ladder", "lagoon", "lantern", "laptop", "lava", "lemon", "leopard", "lilac",
+	"linen", "lobster", "locket", "lotus", "magnet", "mango", "maple", "marble",
+	"meadow", "melon", "mirror", "mitten", "monkey", "mosaic", "muffin", "nectar",
+	"needle", "nickel", "noodle", "nutmeg", "oasis", "ocean", "olive", "onion",
+	"opal", "orange", "orbit", "orchid", "otter", "oyster", "paddle", "panda",
+	"paper", "parrot", "peach", "pebble", "pencil", "pepper", "piano", "pickle",
+	"pigeon", "pillow", "pine", "pirate", "planet", "plum", "pocket", "pony",
+	"potato", "prism", "pumpkin", "puzzle", "quartz", "quill", "rabbit", "radar",
+	"radish", "raven", "ribbon", "river", "robot", "rocket", "saddle", "salmon",
+	"sandal", "satin", "scarf", "shadow", "shell", "silver", "sketch", "sloth",
+}
+
+// fingerprintEmoji maps each 6-bit group of a fingerprint to an emoji
+var fingerprintEmoji = [64]string{
+	"🐶", "🐱", "🐭", "🐹", "🐰", "🦊", "🐻", "🐼",
+	"🐨", "🐯", "🦁", "🐮", "🐷", "🐸", "🐵", "🐔",
+	"🐧", "🐦", "🐤", "🦆", "🦅", "🦉", "🐺", "🐗",
+	"🐴", "🦄", "🐝", "🐛", "🦋", "🐌", "🐞", "🐢",
+	"🐍", "🦎", "🐙", "🦑", "🦀", "🐡", "🐠", "🐟",
+	"🐬", "🐳", "🦈", "🐊", "🐅", "🐘", "🦒", "🦓",
+	"🍎", "🍐", "🍊", "🍋", "🍌", "🍉", "🍇", "🍓",
+	"🍒", "🍑", "🍍", "🥝", "🍅", "🥑", "🌽", "🥕",
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// ListDevices returns the devices enrolled for the account
+func (c *Client) ListDevices(ctx context.Context) ([]*Device, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	devicesData, err := c.getDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]*Device, 0, len(devicesData))
+	for _, deviceData := range devicesData {
+		devices = append(devices, convertDevice(deviceData))
+	}
+
+	return devices, nil
+}
+
+func (c *Client) getDevices(ctx context.Context) ([]*openapi.Device, error) {
+	var url = fmt.Sprintf("%s/v1/devices", c.baseURL)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var apiResponse openapi.GetDevicesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response")
+	}
+
+	return apiResponse.Devices, nil
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestDevice_ListDevices() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-list-devices-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	deviceCredentials, err := NewDeviceCredentials()
+	require.NoError(t, err)
+
+	device, err := s.client.AuthorizeDevice(ctx, AuthorizeDeviceParams{
+		Name:                "Tablet",
+		SigningPublicKey:    deviceCredentials.SigningPublicKey,
+		EncryptionPublicKey: deviceCredentials.EncryptionPublicKey,
+	})
+	require.NoError(t, err)
+
+	// Act
+	devices, err := s.client.ListDevices(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, device.ID, devices[0].ID)
+	assert.Equal(t, "Tablet", devices[0].Name)
+	assert.Equal(t, deviceCredentials.EncryptionPublicKey, devices[0].EncryptionPublicKey)
+}
+
+func (s *ClientSuite) TestDevice_ListDevices_Unauthorized() {
+	t := s.T()
+	ctx := context.Background()
+
+	devices, err := s.client.ListDevices(ctx)
+
+	require.Error(t, err)
+	assert.Nil(t, devices)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
@@ -11,7 +11,90 @@ import (
 	"github.com/thingsdiary/client-go/openapi"
 )
 
+// diaryKeyring holds the decrypted keys of a diary indexed by key ID
+type diaryKeyring struct {
+	activeKeyID string
+	keys        map[string][]byte
+}
+
+// get returns the decrypted diary key with the given ID
+func (k *diaryKeyring) get(keyID string) ([]byte, error) {
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, errors.Errorf("diary key %s not found", keyID)
+	}
+
+	return key, nil
+}
+
+// active returns the decrypted active diary key
+func (k *diaryKeyring) active() []byte {
+	return k.keys[k.activeKeyID]
+}
+
 func (c *Client) getActiveDiaryKey(ctx context.Context, diaryID string) (*openapi.DiaryEncryptionKey, error) {
+	keys, err := c.getDiaryKeys(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return activeDiaryKey(keys)
+}
+
+// activeDiaryKey returns the key new content of a diary is encrypted under
+func activeDiaryKey(keys []openapi.DiaryEncryptionKey) (*openapi.DiaryEncryptionKey, error) {
+	for i := range keys {
+		if keys[i].Status == openapi.Active {
+			return &keys[i], nil
+		}
+	}
+
+	return nil, errors.New("no active encryption key found")
+}
+
+// getDiaryKeyring fetches and decrypts every diary key available to the current account.
+// Entities written before a key rotation stay encrypted under older keys, so reads
+// must pick the key referenced by the entity rather than the active one.
+func (c *Client) getDiaryKeyring(ctx context.Context, diaryID string) (*diaryKeyring, error) {
+	keys, err := c.getDiaryKeys(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newDiaryKeyring(keys)
+}
+
+// newDiaryKeyring decrypts diary keys with the account encryption key
+func (c *Client) newDiaryKeyring(keys []openapi.DiaryEncryptionKey) (*diaryKeyring, error) {
+	keyring := diaryKeyring{
+		keys: make(map[string][]byte, len(keys)),
+	}
+
+	for i := range keys {
+		decryptedDiaryKey, err := decryptWithPrivateKey(
+			keys[i].Value,
+			c.credentials.EncryptionPrivateKey,
+			c.credentials.EncryptionPublicKey,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt diary key")
+		}
+
+		keyring.keys[keys[i].Id] = decryptedDiaryKey
+
+		if keys[i].Status == openapi.Active {
+			keyring.activeKeyID = keys[i].Id
+		}
+	}
+
+	if keyring.activeKeyID == "" {
+		return nil, errors.New("no active encryption key found")
+	}
+
+	return &keyring, nil
+}
+
+func (c *Client) getDiaryKeys(ctx context.Context, diaryID string) ([]openapi.DiaryEncryptionKey, error) {
 	// TODO: Implement client-side caching for keys (TTL ~5min)
 	url := fmt.Sprintf("%s/v1/diaries/%s/keys", c.baseURL, diaryID)
 
@@ -43,11 +126,5 @@ func (c *Client) getActiveDiaryKey(ctx context.Context, diaryID string) (*openap
 		return nil, errors.Wrap(err, "failed to decode response")
 	}
 
-	for i := range apiResponse.Keys {
-		if apiResponse.Keys[i].Status == openapi.Active {
-			return &apiResponse.Keys[i], nil
-		}
-	}
-
-	return nil, errors.New("no active encryption key found")
+	return apiResponse.Keys, nil
 }
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// ListDiaryMembers returns every account with access to a diary, including the owner
+func (c *Client) ListDiaryMembers(ctx context.Context, diaryID string) ([]*DiaryMember, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	membersData, err := c.getDiaryMembers(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*DiaryMember, 0, len(membersData))
+	for _, memberData := range membersData {
+		members = append(members, convertDiaryMember(memberData))
+	}
+
+	return members, nil
+}
+
+func (c *Client) getDiaryMembers(ctx context.Context, diaryID string) ([]*openapi.DiaryMember, error) {
+	var url = fmt.Sprintf("%s/v1/diaries/%s/members", c.baseURL, diaryID)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDiaryNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var apiResponse openapi.GetDiaryMembersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response")
+	}
+
+	return apiResponse.Members, nil
+}
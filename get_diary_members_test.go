@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestDiary_ListDiaryMembers() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-list-members-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	_, memberCredentials := s.registerMember(ctx, "test-list-members-member")
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Family Diary"})
+	require.NoError(t, err)
+
+	_, err = s.client.ShareDiary(ctx, diary.ID, memberCredentials.EncryptionPublicKey, DiaryRoleViewer)
+	require.NoError(t, err)
+
+	// Act
+	members, err := s.client.ListDiaryMembers(ctx, diary.ID)
+
+	// Assert: Owner and viewer are listed
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	roles := map[DiaryRole]*DiaryMember{}
+	for _, member := range members {
+		roles[member.Role] = member
+	}
+
+	require.Contains(t, roles, DiaryRoleOwner)
+	require.Contains(t, roles, DiaryRoleViewer)
+	assert.Equal(t, memberCredentials.EncryptionPublicKey, roles[DiaryRoleViewer].EncryptionPublicKey)
+}
@@ -5,33 +5,53 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/pkg/errors"
+	"github.com/samber/mo"
 
 	"github.com/thingsdiary/client-go/openapi"
 )
 
-func (c *Client) GetEntries(ctx context.Context, diaryID string) ([]*Entry, error) {
+// GetEntriesParams filters the entries returned by GetEntries. Filtering happens
+// after decryption, so the server never learns tag names.
+type GetEntriesParams struct {
+	// Tags keeps only entries carrying every listed tag, compared case-insensitively
+	Tags []string
+
+	// TopicID keeps only entries of the topic
+	TopicID mo.Option[string]
+}
+
+// matches reports whether the entry passes the filter
+func (p GetEntriesParams) matches(entry *Entry) bool {
+	if topicID, ok := p.TopicID.Get(); ok && entry.TopicID.OrEmpty() != topicID {
+		return false
+	}
+
+	for _, tag := range p.Tags {
+		if !entry.HasTag(tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetEntries returns the entries of a diary passing every filter, ordered by
+// effective date as by SortEntries
+func (c *Client) GetEntries(ctx context.Context, diaryID string, params ...GetEntriesParams) ([]*Entry, error) {
 	if c.credentials == nil {
 		return nil, ErrUnauthorized
 	}
 
 	// Get encryption keys
-	key, err := c.getActiveDiaryKey(ctx, diaryID)
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Decrypt diary key
-	decryptedDiaryKey, err := decryptWithPrivateKey(
-		key.Value,
-		c.credentials.EncryptionPrivateKey,
-		c.credentials.EncryptionPublicKey,
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to decrypt diary key")
-	}
-
 	// Get entries data
 	entriesData, err := c.getEntries(ctx, diaryID)
 	if err != nil {
@@ -40,20 +60,86 @@ func (c *Client) GetEntries(ctx context.Context, diaryID string) ([]*Entry, erro
 
 	entries := make([]*Entry, 0, len(entriesData))
 	for _, entryData := range entriesData {
-		entry, err := c.decryptEntry(entryData, decryptedDiaryKey)
+		diaryKey, err := keyring.get(entryData.Encryption.DiaryKeyId)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := c.decryptEntry(entryData, diaryKey)
 		if err != nil {
 			return nil, err
 		}
 
+		if !matchesAll(params, entry) {
+			continue
+		}
+
 		entries = append(entries, entry)
 	}
 
+	SortEntries(entries)
+
 	return entries, nil
 }
 
+// matchesAll reports whether the entry passes every filter
+func matchesAll(params []GetEntriesParams, entry *Entry) bool {
+	for _, p := range params {
+		if !p.matches(entry) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (c *Client) getEntries(ctx context.Context, diaryID string) ([]*openapi.Entry, error) {
-	var url = fmt.Sprintf("%s/v1/diaries/%s/entries", c.baseURL, diaryID)
-	req, err := c.newAuthenticatedRequest(ctx, http.MethodGet, url, nil)
+	return c.getEntriesSince(ctx, diaryID, 0)
+}
+
+// getEntriesSince fetches every page of entries with a version greater than sinceVersion.
+// Servers without version filtering return all entries, so the version is checked here too.
+func (c *Client) getEntriesSince(ctx context.Context, diaryID string, sinceVersion uint64) ([]*openapi.Entry, error) {
+	var entries []*openapi.Entry
+	params := openapi.GetEntriesParams{}
+	if sinceVersion > 0 {
+		params.SinceVersion = &sinceVersion
+	}
+
+	seen := map[string]bool{}
+	for {
+		page, err := c.getEntriesPage(ctx, diaryID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Entries {
+			if item.Version > sinceVersion {
+				entries = append(entries, item)
+			}
+		}
+
+		token, err := nextPageToken(page.NextPageToken, seen)
+		if err != nil {
+			return nil, err
+		}
+		if token == nil {
+			return entries, nil
+		}
+
+		params.NextPageToken = token
+	}
+}
+
+func (c *Client) getEntriesPage(ctx context.Context, diaryID string, params openapi.GetEntriesParams) (*openapi.GetEntriesResponse, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/v1/diaries/%s/entries", c.baseURL, diaryID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse URL")
+	}
+
+	u.RawQuery = pageQuery(params.NextPageToken, params.SinceVersion).Encode()
+
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create request")
 	}
@@ -81,5 +167,36 @@ func (c *Client) getEntries(ctx context.Context, diaryID string) ([]*openapi.Ent
 		return nil, errors.Wrap(err, "failed to decode response")
 	}
 
-	return apiResponse.Entries, nil
+	return &apiResponse, nil
+}
+
+// nextPageToken returns the token of the page after one, nil after the last page.
+// seen holds the tokens of the pages read so far; a token served twice would make
+// the listing loop forever, so it is rejected.
+func nextPageToken(token mo.Option[string], seen map[string]bool) (*string, error) {
+	next, ok := token.Get()
+	if !ok || next == "" {
+		return nil, nil
+	}
+
+	if seen[next] {
+		return nil, errors.Errorf("server repeated page token %q", next)
+	}
+	seen[next] = true
+
+	return &next, nil
+}
+
+// pageQuery builds the query of a paginated list request
+func pageQuery(nextPageToken *string, sinceVersion *uint64) url.Values {
+	query := url.Values{}
+	if nextPageToken != nil {
+		query.Set("next_page_token", *nextPageToken)
+	}
+
+	if sinceVersion != nil {
+		query.Set("since_version", strconv.FormatUint(*sinceVersion, 10))
+	}
+
+	return query
 }
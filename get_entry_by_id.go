@@ -17,29 +17,24 @@ func (c *Client) GetEntryByID(ctx context.Context, diaryID, entryID string) (*En
 	}
 
 	// Get encryption keys
-	key, err := c.getActiveDiaryKey(ctx, diaryID)
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Decrypt diary key
-	decryptedDiaryKey, err := decryptWithPrivateKey(
-		key.Value,
-		c.credentials.EncryptionPrivateKey,
-		c.credentials.EncryptionPublicKey,
-	)
+	// Get entry data
+	entryData, err := c.getEntry(ctx, diaryID, entryID)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decrypt diary key")
+		return nil, err
 	}
 
-	// Get entry data
-	entryData, err := c.getEntry(ctx, diaryID, entryID)
+	diaryKey, err := keyring.get(entryData.Encryption.DiaryKeyId)
 	if err != nil {
 		return nil, err
 	}
 
 	// Decrypt and return entry
-	entry, err := c.decryptEntry(entryData, decryptedDiaryKey)
+	entry, err := c.decryptEntry(entryData, diaryKey)
 	if err != nil {
 		return nil, err
 	}
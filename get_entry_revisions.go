@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// GetEntryRevisions returns the prior versions of an entry kept by the client's
+// RevisionStore, oldest first. The current version is not included.
+func (c *Client) GetEntryRevisions(ctx context.Context, diaryID, entryID string) ([]*Entry, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	if c.revisions == nil {
+		return nil, errors.New("revision history is disabled, see WithRevisionStore")
+	}
+
+	revisionsData, err := c.revisions.List(diaryID, entryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list revisions")
+	}
+
+	if len(revisionsData) == 0 {
+		return nil, nil
+	}
+
+	// Get encryption keys
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*Entry, 0, len(revisionsData))
+	for _, revisionData := range revisionsData {
+		diaryKey, err := keyring.get(revisionData.Encryption.DiaryKeyId)
+		if err != nil {
+			return nil, err
+		}
+
+		revision, err := c.decryptEntry(revisionData, diaryKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decrypt revision %d", revisionData.Version)
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// saveEntryRevision stores the current version of an entry before it is overwritten
+func (c *Client) saveEntryRevision(ctx context.Context, diaryID, entryID string) error {
+	if c.revisions == nil {
+		return nil
+	}
+
+	current, err := c.getEntry(ctx, diaryID, entryID)
+	if errors.Is(err, ErrEntryNotFound) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get current entry version")
+	}
+
+	if err := c.revisions.Save(diaryID, current); err != nil {
+		return errors.Wrap(err, "failed to save entry revision")
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestEntry_RevisionsAndRevert() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	client := NewClient(WithBaseURL("http://localhost:8081/api"), WithRevisionStore(NewRevisionStore(t.TempDir())))
+
+	var login = fmt.Sprintf("test-entry-revisions-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	entry, err := client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "first draft"})
+	require.NoError(t, err)
+
+	params := entry.PutParams()
+	params.Content = "second draft"
+	_, err = client.PutEntry(ctx, diary.ID, entry.ID, params)
+	require.NoError(t, err)
+
+	// Act
+	revisions, err := client.GetEntryRevisions(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+
+	reverted, err := client.RevertEntry(ctx, diary.ID, entry.ID, entry.Version)
+	require.NoError(t, err)
+
+	_, missingErr := client.RevertEntry(ctx, diary.ID, entry.ID, 1)
+
+	revisionsAfterRevert, err := client.GetEntryRevisions(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, revisions, 1)
+	assert.Equal(t, "first draft", revisions[0].Content)
+	assert.Equal(t, "first draft", reverted.Content)
+	assert.ErrorIs(t, missingErr, ErrRevisionNotFound)
+	require.Len(t, revisionsAfterRevert, 2)
+	assert.Equal(t, "second draft", revisionsAfterRevert[1].Content)
+}
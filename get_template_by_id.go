@@ -17,29 +17,24 @@ func (c *Client) GetTemplateByID(ctx context.Context, diaryID, templateID string
 	}
 
 	// Get encryption keys
-	key, err := c.getActiveDiaryKey(ctx, diaryID)
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Decrypt diary key
-	decryptedDiaryKey, err := decryptWithPrivateKey(
-		key.Value,
-		c.credentials.EncryptionPrivateKey,
-		c.credentials.EncryptionPublicKey,
-	)
+	// Get template from API
+	apiTemplate, err := c.getTemplate(ctx, diaryID, templateID)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decrypt diary key")
+		return nil, err
 	}
 
-	// Get template from API
-	apiTemplate, err := c.getTemplate(ctx, diaryID, templateID)
+	diaryKey, err := keyring.get(apiTemplate.Encryption.DiaryKeyId)
 	if err != nil {
 		return nil, err
 	}
 
 	// Decrypt template
-	return c.decryptTemplate(apiTemplate, decryptedDiaryKey)
+	return c.decryptTemplate(apiTemplate, diaryKey)
 }
 
 func (c *Client) getTemplate(ctx context.Context, diaryID, templateID string) (*openapi.Template, error) {
@@ -103,13 +98,19 @@ func (c *Client) decryptTemplate(apiTemplate *openapi.Template, diaryKey []byte)
 	}
 
 	template := Template{
-		ID:        apiTemplate.Id,
-		DiaryID:   string(apiTemplate.DiaryId),
-		Content:   templateDetails.Content,
-		CreatedAt: apiTemplate.CreatedAt,
-		UpdatedAt: apiTemplate.UpdatedAt,
-		DeletedAt: apiTemplate.DeletedAt,
-		Version:   apiTemplate.Version,
+		ID:          apiTemplate.Id,
+		DiaryID:     string(apiTemplate.DiaryId),
+		Name:        templateDetails.Name,
+		Description: templateDetails.Description,
+		Icon:        templateDetails.Icon,
+		Color:       templateDetails.Color,
+		Content:     templateDetails.Content,
+		Prompts:     templateDetails.Prompts,
+		CreatedAt:   apiTemplate.CreatedAt,
+		UpdatedAt:   apiTemplate.UpdatedAt,
+		DeletedAt:   apiTemplate.DeletedAt,
+		Version:     apiTemplate.Version,
+		unknown:     templateDetails.unknown,
 	}
 
 	return &template, nil
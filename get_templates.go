@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -17,21 +20,11 @@ func (c *Client) GetTemplates(ctx context.Context, diaryID string) ([]*Template,
 	}
 
 	// Get encryption keys
-	key, err := c.getActiveDiaryKey(ctx, diaryID)
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Decrypt diary key
-	decryptedDiaryKey, err := decryptWithPrivateKey(
-		key.Value,
-		c.credentials.EncryptionPrivateKey,
-		c.credentials.EncryptionPublicKey,
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to decrypt diary key")
-	}
-
 	// Get templates data
 	templatesData, err := c.getTemplates(ctx, diaryID)
 	if err != nil {
@@ -40,7 +33,12 @@ func (c *Client) GetTemplates(ctx context.Context, diaryID string) ([]*Template,
 
 	templates := make([]*Template, 0, len(templatesData))
 	for _, templateData := range templatesData {
-		template, err := c.decryptTemplate(templateData, decryptedDiaryKey)
+		diaryKey, err := keyring.get(templateData.Encryption.DiaryKeyId)
+		if err != nil {
+			return nil, err
+		}
+
+		template, err := c.decryptTemplate(templateData, diaryKey)
 		if err != nil {
 			return nil, err
 		}
@@ -48,12 +46,75 @@ func (c *Client) GetTemplates(ctx context.Context, diaryID string) ([]*Template,
 		templates = append(templates, template)
 	}
 
+	sortTemplatesByName(templates)
+
 	return templates, nil
 }
 
+// sortTemplatesByName orders templates by name, case-insensitively. Templates
+// without a name come last; ties are broken by ID to keep the order stable.
+func sortTemplatesByName(templates []*Template) {
+	sort.Slice(templates, func(i, j int) bool {
+		a, b := templates[i], templates[j]
+		if (a.Name == "") != (b.Name == "") {
+			return a.Name != ""
+		}
+
+		if nameA, nameB := strings.ToLower(a.Name), strings.ToLower(b.Name); nameA != nameB {
+			return nameA < nameB
+		}
+
+		return a.ID < b.ID
+	})
+}
+
 func (c *Client) getTemplates(ctx context.Context, diaryID string) ([]*openapi.Template, error) {
-	var url = fmt.Sprintf("%s/v1/diaries/%s/templates", c.baseURL, diaryID)
-	req, err := c.newAuthenticatedRequest(ctx, http.MethodGet, url, nil)
+	return c.getTemplatesSince(ctx, diaryID, 0)
+}
+
+// getTemplatesSince fetches every page of templates with a version greater than sinceVersion.
+// Servers without version filtering return all templates, so the version is checked here too.
+func (c *Client) getTemplatesSince(ctx context.Context, diaryID string, sinceVersion uint64) ([]*openapi.Template, error) {
+	var templates []*openapi.Template
+	params := openapi.GetTemplatesParams{}
+	if sinceVersion > 0 {
+		params.SinceVersion = &sinceVersion
+	}
+
+	seen := map[string]bool{}
+	for {
+		page, err := c.getTemplatesPage(ctx, diaryID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Templates {
+			if item.Version > sinceVersion {
+				templates = append(templates, item)
+			}
+		}
+
+		token, err := nextPageToken(page.NextPageToken, seen)
+		if err != nil {
+			return nil, err
+		}
+		if token == nil {
+			return templates, nil
+		}
+
+		params.NextPageToken = token
+	}
+}
+
+func (c *Client) getTemplatesPage(ctx context.Context, diaryID string, params openapi.GetTemplatesParams) (*openapi.GetTemplatesResponse, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/v1/diaries/%s/templates", c.baseURL, diaryID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse URL")
+	}
+
+	u.RawQuery = pageQuery(params.NextPageToken, params.SinceVersion).Encode()
+
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create request")
 	}
@@ -81,5 +142,5 @@ func (c *Client) getTemplates(ctx context.Context, diaryID string) ([]*openapi.T
 		return nil, errors.Wrap(err, "failed to decode response")
 	}
 
-	return apiResponse.Templates, nil
+	return &apiResponse, nil
 }
@@ -151,3 +151,46 @@ func (s *ClientSuite) TestTemplate_GetTemplates_DiaryNotFound() {
 	assert.ErrorIs(t, err, ErrDiaryNotFound)
 	assert.Nil(t, templates)
 }
+
+func (s *ClientSuite) TestTemplate_GetTemplates_SortedByName() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-templates-sorted-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Test Diary"})
+	require.NoError(t, err)
+
+	_, err = s.client.CreateTemplate(ctx, diary.ID, CreateTemplateParams{Name: "Weekly review", Content: "Week {{date}}"})
+	require.NoError(t, err)
+
+	created, err := s.client.CreateTemplate(ctx, diary.ID, CreateTemplateParams{
+		Name:        "Evening reflection",
+		Description: "Three questions before bed",
+		Icon:        "moon",
+		Color:       "#223344",
+		Prompts:     []string{"What went well?", "What did I learn?"},
+	})
+	require.NoError(t, err)
+
+	// Act
+	templates, err := s.client.GetTemplates(ctx, diary.ID)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "Evening reflection", templates[0].Name)
+	assert.Equal(t, "Weekly review", templates[1].Name)
+
+	assert.Equal(t, created.ID, templates[0].ID)
+	assert.Equal(t, "Three questions before bed", templates[0].Description)
+	assert.Equal(t, "moon", templates[0].Icon)
+	assert.Equal(t, "#223344", templates[0].Color)
+	assert.Equal(t, []string{"What went well?", "What did I learn?"}, templates[0].Prompts)
+}
@@ -17,29 +17,24 @@ func (c *Client) GetTopicByID(ctx context.Context, diaryID, topicID string) (*To
 	}
 
 	// Get encryption keys
-	key, err := c.getActiveDiaryKey(ctx, diaryID)
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Decrypt diary key
-	decryptedDiaryKey, err := decryptWithPrivateKey(
-		key.Value,
-		c.credentials.EncryptionPrivateKey,
-		c.credentials.EncryptionPublicKey,
-	)
+	// Get topic data
+	topicData, err := c.getTopic(ctx, diaryID, topicID)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decrypt diary key")
+		return nil, err
 	}
 
-	// Get topic data
-	topicData, err := c.getTopic(ctx, diaryID, topicID)
+	diaryKey, err := keyring.get(topicData.Encryption.DiaryKeyId)
 	if err != nil {
 		return nil, err
 	}
 
 	// Decrypt and return topic
-	return c.decryptTopic(topicData, decryptedDiaryKey)
+	return c.decryptTopic(topicData, diaryKey)
 }
 
 func (c *Client) getTopic(ctx context.Context, diaryID, topicID string) (*openapi.Topic, error) {
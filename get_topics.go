@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/pkg/errors"
 
@@ -17,21 +18,11 @@ func (c *Client) GetTopics(ctx context.Context, diaryID string) ([]*Topic, error
 	}
 
 	// Get encryption keys
-	key, err := c.getActiveDiaryKey(ctx, diaryID)
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Decrypt diary key
-	decryptedDiaryKey, err := decryptWithPrivateKey(
-		key.Value,
-		c.credentials.EncryptionPrivateKey,
-		c.credentials.EncryptionPublicKey,
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to decrypt diary key")
-	}
-
 	// Get topics data
 	topicsData, err := c.getTopics(ctx, diaryID)
 	if err != nil {
@@ -40,7 +31,12 @@ func (c *Client) GetTopics(ctx context.Context, diaryID string) ([]*Topic, error
 
 	topics := make([]*Topic, 0, len(topicsData))
 	for _, topicData := range topicsData {
-		topic, err := c.decryptTopic(topicData, decryptedDiaryKey)
+		diaryKey, err := keyring.get(topicData.Encryption.DiaryKeyId)
+		if err != nil {
+			return nil, err
+		}
+
+		topic, err := c.decryptTopic(topicData, diaryKey)
 		if err != nil {
 			return nil, err
 		}
@@ -52,8 +48,52 @@ func (c *Client) GetTopics(ctx context.Context, diaryID string) ([]*Topic, error
 }
 
 func (c *Client) getTopics(ctx context.Context, diaryID string) ([]*openapi.Topic, error) {
-	var url = fmt.Sprintf("%s/v1/diaries/%s/topics", c.baseURL, diaryID)
-	req, err := c.newAuthenticatedRequest(ctx, http.MethodGet, url, nil)
+	return c.getTopicsSince(ctx, diaryID, 0)
+}
+
+// getTopicsSince fetches every page of topics with a version greater than sinceVersion.
+// Servers without version filtering return all topics, so the version is checked here too.
+func (c *Client) getTopicsSince(ctx context.Context, diaryID string, sinceVersion uint64) ([]*openapi.Topic, error) {
+	var topics []*openapi.Topic
+	params := openapi.GetTopicsParams{}
+	if sinceVersion > 0 {
+		params.SinceVersion = &sinceVersion
+	}
+
+	seen := map[string]bool{}
+	for {
+		page, err := c.getTopicsPage(ctx, diaryID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Topics {
+			if item.Version > sinceVersion {
+				topics = append(topics, item)
+			}
+		}
+
+		token, err := nextPageToken(page.NextPageToken, seen)
+		if err != nil {
+			return nil, err
+		}
+		if token == nil {
+			return topics, nil
+		}
+
+		params.NextPageToken = token
+	}
+}
+
+func (c *Client) getTopicsPage(ctx context.Context, diaryID string, params openapi.GetTopicsParams) (*openapi.GetTopicsResponse, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/v1/diaries/%s/topics", c.baseURL, diaryID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse URL")
+	}
+
+	u.RawQuery = pageQuery(params.NextPageToken, params.SinceVersion).Encode()
+
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create request")
 	}
@@ -81,5 +121,5 @@ func (c *Client) getTopics(ctx context.Context, diaryID string) ([]*openapi.Topi
 		return nil, errors.Wrap(err, "failed to decode response")
 	}
 
-	return apiResponse.Topics, nil
+	return &apiResponse, nil
 }
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxDailyFileSize bounds the size of a file read by ImportDailyFiles
+const maxDailyFileSize = 16 << 20
+
+// dailyFileDatePattern finds the date in a path like 2024-01-05.md or 2024/01/05.txt
+var dailyFileDatePattern = regexp.MustCompile(`(\d{4})[-_/.]?(\d{2})[-_/.]?(\d{2})`)
+
+// dailyFileExtensions are the file types ImportDailyFiles reads
+var dailyFileExtensions = map[string]bool{
+	".txt":      true,
+	".md":       true,
+	".markdown": true,
+}
+
+// ImportDailyFiles imports a folder holding one text file per day, where the date
+// is part of the path, e.g. 2024-01-05.md or 2024/01/05.txt. Each file becomes an
+// entry authored at midnight of its day and @tags become entry tags. Files without a
+// date in their path are reported as warnings.
+func (c *Client) ImportDailyFiles(ctx context.Context, diaryID, dir string, params TextImportParams) (*TextImportReport, error) {
+	report, err := readDailyFiles(os.DirFS(dir), params.location())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.importTextEntries(ctx, diaryID, report, params)
+}
+
+// readDailyFiles reads the entries of a one-file-per-day folder
+func readDailyFiles(fsys fs.FS, loc *time.Location) (*TextImportReport, error) {
+	report := TextImportReport{}
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(d.Name(), ".") && name != "." {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() || !dailyFileExtensions[strings.ToLower(path.Ext(name))] {
+			return nil
+		}
+
+		date, ok := dailyFileDate(strings.TrimSuffix(name, path.Ext(name)), loc)
+		if !ok {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s: no date in file name, skipped", name))
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > maxDailyFileSize {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s: file is too large, skipped", name))
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		content := strings.TrimRight(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n\t ")
+		if strings.TrimSpace(content) == "" {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s: file is empty, skipped", name))
+			return nil
+		}
+
+		report.Entries = append(report.Entries, TextImportEntry{
+			Source:  name,
+			Date:    date,
+			Title:   entryTitle(content),
+			Content: content,
+			Tags:    jrnlTags(content),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read daily files")
+	}
+
+	return &report, nil
+}
+
+// dailyFileDate returns the last valid date found in a path
+func dailyFileDate(name string, loc *time.Location) (time.Time, bool) {
+	matches := dailyFileDatePattern.FindAllStringSubmatch(name, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		value := matches[i][1] + "-" + matches[i][2] + "-" + matches[i][3]
+		if date, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+			return date, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDailyFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"2024-01-05.md":           {Data: []byte("# Friday\n\nShipped it @work\n\n")},
+		"2024/01/06.txt":          {Data: []byte("Saturday\r\nrest")},
+		"notes/ideas.md":          {Data: []byte("No date here")},
+		"2024-01-07.md":           {Data: []byte("  \n")},
+		"2024-13-40.md":           {Data: []byte("Invalid date")},
+		"image-2024-01-05.png":    {Data: []byte("binary")},
+		".obsidian/2024-01-08.md": {Data: []byte("hidden")},
+	}
+
+	report, err := readDailyFiles(fsys, time.UTC)
+	require.NoError(t, err)
+
+	require.Len(t, report.Entries, 2)
+	bySource := map[string]TextImportEntry{}
+	for _, entry := range report.Entries {
+		bySource[entry.Source] = entry
+	}
+
+	friday := bySource["2024-01-05.md"]
+	assert.Equal(t, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), friday.Date)
+	assert.Equal(t, "Friday", friday.Title)
+	assert.Equal(t, "# Friday\n\nShipped it @work", friday.Content)
+	assert.Equal(t, []string{"work"}, friday.Tags)
+
+	saturday := bySource["2024/01/06.txt"]
+	assert.Equal(t, time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), saturday.Date)
+	assert.Equal(t, "Saturday\nrest", saturday.Content)
+
+	assert.ElementsMatch(t, []string{
+		"2024-01-07.md: file is empty, skipped",
+		"2024-13-40.md: no date in file name, skipped",
+		"notes/ideas.md: no date in file name, skipped",
+	}, report.Warnings)
+}
+
+func TestDailyFileDate(t *testing.T) {
+	date, ok := dailyFileDate("journal/2023/12/31", time.UTC)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), date)
+
+	date, ok = dailyFileDate("20230102-notes", time.UTC)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), date)
+
+	_, ok = dailyFileDate("notes", time.UTC)
+	assert.False(t, ok)
+}
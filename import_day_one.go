@@ -0,0 +1,399 @@
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+)
+
+// maxDayOneFileSize bounds files read from a Day One export into memory
+const maxDayOneFileSize = 256 << 20
+
+// dayOneMomentPattern matches Markdown images referencing Day One media
+var dayOneMomentPattern = regexp.MustCompile(`!\[([^\]]*)\]\(dayone-moment://([^)\s]+)\)`)
+
+// DayOneImportParams contains options for importing a Day One export
+type DayOneImportParams struct {
+	// DiaryIDs maps journal names to the diaries they are imported into. Other
+	// journals go into the diary with the same title, created when missing.
+	DiaryIDs map[string]string
+}
+
+// DayOneImportResult describes what ImportDayOne wrote
+type DayOneImportResult struct {
+	// Diaries maps journal names to the diaries they were imported into
+	Diaries map[string]string
+
+	Entries int
+	Topics  int
+	Photos  int
+
+	// SkippedEntries counts entries left untouched because an earlier import created them
+	SkippedEntries int
+
+	// MissingPhotos counts photos referenced by entries but absent from the export
+	MissingPhotos int
+}
+
+// dayOneExport is a journal file of a Day One JSON export
+type dayOneExport struct {
+	Entries []dayOneEntry `json:"entries"`
+}
+
+type dayOneEntry struct {
+	UUID         string          `json:"uuid"`
+	CreationDate time.Time       `json:"creationDate"`
+	Text         string          `json:"text"`
+	Starred      bool            `json:"starred"`
+	Tags         []string        `json:"tags"`
+	Location     *dayOneLocation `json:"location"`
+	Weather      *dayOneWeather  `json:"weather"`
+	Photos       []dayOnePhoto   `json:"photos"`
+}
+
+type dayOneLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	PlaceName string  `json:"placeName"`
+}
+
+type dayOneWeather struct {
+	TemperatureCelsius    float64 `json:"temperatureCelsius"`
+	ConditionsDescription string  `json:"conditionsDescription"`
+	RelativeHumidity      float64 `json:"relativeHumidity"`
+}
+
+type dayOnePhoto struct {
+	Identifier string `json:"identifier"`
+	MD5        string `json:"md5"`
+	Type       string `json:"type"`
+	Filename   string `json:"filename"`
+}
+
+// dayOneJournal is a journal read from an export
+type dayOneJournal struct {
+	Name    string
+	Entries []dayOneEntry
+}
+
+// ImportDayOne imports a Day One JSON export zip. Each journal goes into a diary,
+// starred entries are bookmarked and every tag becomes a topic; an entry is filed
+// under the topic of its first tag and keeps all tags. Photos are uploaded as
+// attachments and their inline references point to the attachments.
+//
+// Entry and topic IDs are derived from the Day One IDs, so importing the same
+// export again only adds what is missing. Entries and topics an earlier import
+// created are left as they are, including changes made to them since, and so
+// are entries deleted since. The original creation time becomes the entry's
+// AuthoredAt.
+func (c *Client) ImportDayOne(ctx context.Context, r io.ReaderAt, size int64, params DayOneImportParams) (*DayOneImportResult, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open Day One export")
+	}
+
+	journals, err := readDayOneExport(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	diaryIDs, err := c.dayOneDiaries(ctx, journals, params.DiaryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := DayOneImportResult{
+		Diaries: diaryIDs,
+	}
+
+	for _, journal := range journals {
+		if err := c.importDayOneJournal(ctx, archive, diaryIDs[journal.Name], journal, &result); err != nil {
+			return &result, errors.Wrapf(err, "failed to import journal %s", journal.Name)
+		}
+	}
+
+	return &result, nil
+}
+
+// dayOneDiaries resolves the diary of every journal, creating missing ones
+func (c *Client) dayOneDiaries(ctx context.Context, journals []dayOneJournal, explicit map[string]string) (map[string]string, error) {
+	diaries, err := c.GetDiaries(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get diaries")
+	}
+
+	byTitle := map[string]string{}
+	for _, diary := range diaries {
+		if _, ok := byTitle[diary.Title]; !ok {
+			byTitle[diary.Title] = diary.ID
+		}
+	}
+
+	diaryIDs := make(map[string]string, len(journals))
+	for _, journal := range journals {
+		if diaryID, ok := explicit[journal.Name]; ok {
+			diaryIDs[journal.Name] = diaryID
+			continue
+		}
+
+		if diaryID, ok := byTitle[journal.Name]; ok {
+			diaryIDs[journal.Name] = diaryID
+			continue
+		}
+
+		diary, err := c.CreateDiary(ctx, CreateDiaryParams{Title: journal.Name})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create diary for journal %s", journal.Name)
+		}
+
+		diaryIDs[journal.Name] = diary.ID
+	}
+
+	return diaryIDs, nil
+}
+
+func (c *Client) importDayOneJournal(ctx context.Context, archive *zip.Reader, diaryID string, journal dayOneJournal, result *DayOneImportResult) error {
+	existingTopics, err := c.getTopics(ctx, diaryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get topics")
+	}
+
+	existingEntries, err := c.getEntries(ctx, diaryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get entries")
+	}
+
+	// Topics deleted since are created again, as imported entries are filed under them
+	topicExists := map[string]bool{}
+	for _, topic := range existingTopics {
+		topicExists[topic.Id] = topic.DeletedAt.IsAbsent()
+	}
+
+	entryExists := map[string]bool{}
+	for _, entry := range existingEntries {
+		entryExists[entry.Id] = true
+	}
+
+	topicIDs := map[string]string{}
+	for _, tag := range dayOneTags(journal.Entries) {
+		topicID := dayOneImportID(diaryID, "tag", tag)
+		topicIDs[tag] = topicID
+
+		if topicExists[topicID] {
+			continue
+		}
+
+		if _, err := c.PutTopic(ctx, diaryID, topicID, PutTopicParams{Title: tag}); err != nil {
+			return errors.Wrapf(err, "failed to import tag %s", tag)
+		}
+
+		result.Topics++
+	}
+
+	photos := map[string]*zip.File{}
+	for _, file := range archive.File {
+		if path.Dir(file.Name) == "photos" {
+			photos[path.Base(file.Name)] = file
+		}
+	}
+
+	writes := make([]EntryWrite, 0, len(journal.Entries))
+	for _, entry := range journal.Entries {
+		entryID := dayOneImportID(diaryID, "entry", entry.UUID)
+		if entryExists[entryID] {
+			result.SkippedEntries++
+			continue
+		}
+
+		refs := map[string]AttachmentRef{}
+		for _, photo := range entry.Photos {
+			file, ok := photos[photo.MD5+"."+photo.Type]
+			if !ok {
+				result.MissingPhotos++
+				continue
+			}
+
+			ref, err := c.uploadDayOnePhoto(ctx, diaryID, file, photo)
+			if err != nil {
+				return errors.Wrapf(err, "failed to import photo %s", photo.Identifier)
+			}
+
+			refs[photo.Identifier] = *ref
+			result.Photos++
+		}
+
+		writes = append(writes, EntryWrite{
+			ID:     entryID,
+			Params: dayOneEntryParams(entry, topicIDs, refs),
+		})
+	}
+
+	entries, err := c.PutEntries(ctx, diaryID, writes)
+	result.Entries += len(entries)
+
+	return err
+}
+
+func (c *Client) uploadDayOnePhoto(ctx context.Context, diaryID string, file *zip.File, photo dayOnePhoto) (*AttachmentRef, error) {
+	data, err := readZipFile(file, maxDayOneFileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	name := photo.Filename
+	if name == "" {
+		name = path.Base(file.Name)
+	}
+
+	// Uploading from a seekable reader reuses content already stored in the diary
+	return c.UploadAttachment(ctx, diaryID, bytes.NewReader(data), UploadAttachmentParams{
+		Name:     name,
+		MimeType: "image/" + strings.ToLower(photo.Type),
+	})
+}
+
+// readDayOneExport reads every journal of an export, ordered by name
+func readDayOneExport(archive *zip.Reader) ([]dayOneJournal, error) {
+	var journals []dayOneJournal
+	for _, file := range archive.File {
+		if path.Dir(file.Name) != "." || path.Ext(file.Name) != ".json" {
+			continue
+		}
+
+		data, err := readZipFile(file, maxDayOneFileSize)
+		if err != nil {
+			return nil, err
+		}
+
+		var export dayOneExport
+		if err := json.Unmarshal(data, &export); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", file.Name)
+		}
+
+		journals = append(journals, dayOneJournal{
+			Name:    strings.TrimSuffix(file.Name, ".json"),
+			Entries: export.Entries,
+		})
+	}
+
+	if len(journals) == 0 {
+		return nil, errors.New("no journals found in Day One export")
+	}
+
+	sort.Slice(journals, func(i, j int) bool {
+		return journals[i].Name < journals[j].Name
+	})
+
+	return journals, nil
+}
+
+// dayOneEntryParams converts a Day One entry, pointing its photo references to the
+// uploaded attachments
+func dayOneEntryParams(entry dayOneEntry, topicIDs map[string]string, refs map[string]AttachmentRef) PutEntryParams {
+	params := PutEntryParams{
+		Bookmarked: entry.Starred,
+		Tags:       entry.Tags,
+		AuthoredAt: mo.Some(entry.CreationDate),
+	}
+
+	if len(entry.Tags) > 0 {
+		if topicID, ok := topicIDs[entry.Tags[0]]; ok {
+			params.TopicID = mo.Some(topicID)
+		}
+	}
+
+	if location := entry.Location; location != nil {
+		params.Metadata.Location = mo.Some(Location{
+			Latitude:  location.Latitude,
+			Longitude: location.Longitude,
+			PlaceName: location.PlaceName,
+		})
+	}
+
+	if weather := entry.Weather; weather != nil {
+		params.Metadata.Weather = mo.Some(Weather{
+			TemperatureCelsius: weather.TemperatureCelsius,
+			Condition:          weather.ConditionsDescription,
+			HumidityPercent:    weather.RelativeHumidity,
+		})
+	}
+
+	params.Content = dayOneMomentPattern.ReplaceAllStringFunc(entry.Text, func(match string) string {
+		groups := dayOneMomentPattern.FindStringSubmatch(match)
+
+		ref, ok := refs[groups[2]]
+		if !ok {
+			return match
+		}
+
+		return fmt.Sprintf("![%s](attachment://%s)", groups[1], ref.ID)
+	})
+
+	// Entries keep photos in the order Day One lists them
+	for _, photo := range entry.Photos {
+		if ref, ok := refs[photo.Identifier]; ok {
+			params.Attachments = append(params.Attachments, ref)
+		}
+	}
+
+	return params
+}
+
+// dayOneTags returns the distinct tags of entries, sorted
+func dayOneTags(entries []dayOneEntry) []string {
+	seen := map[string]bool{}
+	var tags []string
+	for _, entry := range entries {
+		for _, tag := range entry.Tags {
+			if tag != "" && !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
+
+// dayOneImportID derives a stable ID for an imported entity, so importing the
+// same export again updates the entities created the first time
+func dayOneImportID(diaryID, kind, id string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("thingsdiary:dayone:"+diaryID+":"+kind+":"+id)).String()
+}
+
+func readZipFile(file *zip.File, limit int64) ([]byte, error) {
+	if file.UncompressedSize64 > uint64(limit) {
+		return nil, errors.Errorf("%s is too large", file.Name)
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", file.Name)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, limit))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", file.Name)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDayOneJournal = `{
+  "metadata": {"version": "1.0"},
+  "entries": [
+    {
+      "uuid": "A1B2C3D4E5F6",
+      "creationDate": "2019-06-01T07:15:00Z",
+      "text": "Beach day\n\n![](dayone-moment://PHOTO1)\n\n![](dayone-moment://MISSING)",
+      "starred": true,
+      "tags": ["Travel", "Summer"],
+      "location": {"latitude": 43.7, "longitude": 7.26, "placeName": "Nice"},
+      "weather": {"temperatureCelsius": 27.5, "conditionsDescription": "Sunny", "relativeHumidity": 60},
+      "photos": [
+        {"identifier": "PHOTO1", "md5": "0cc175b9c0f1b6a831c399e269772661", "type": "jpeg", "filename": "beach.jpeg"},
+        {"identifier": "MISSING", "md5": "ffff", "type": "jpeg"}
+      ]
+    },
+    {
+      "uuid": "F6E5D4C3B2A1",
+      "creationDate": "2019-06-02T21:00:00Z",
+      "text": "Quiet evening"
+    }
+  ]
+}`
+
+func testDayOneArchive(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	return archive
+}
+
+func TestReadDayOneExport(t *testing.T) {
+	archive := testDayOneArchive(t, map[string]string{
+		"Work.json":    `{"entries": []}`,
+		"Journal.json": testDayOneJournal,
+		"photos/0cc175b9c0f1b6a831c399e269772661.jpeg": "a",
+	})
+
+	journals, err := readDayOneExport(archive)
+	require.NoError(t, err)
+
+	require.Len(t, journals, 2)
+	assert.Equal(t, "Journal", journals[0].Name)
+	assert.Equal(t, "Work", journals[1].Name)
+
+	require.Len(t, journals[0].Entries, 2)
+	entry := journals[0].Entries[0]
+	assert.Equal(t, "A1B2C3D4E5F6", entry.UUID)
+	assert.Equal(t, time.Date(2019, 6, 1, 7, 15, 0, 0, time.UTC), entry.CreationDate)
+	assert.True(t, entry.Starred)
+	assert.Equal(t, []string{"Travel", "Summer"}, entry.Tags)
+	require.Len(t, entry.Photos, 2)
+}
+
+func TestReadDayOneExport_NoJournals(t *testing.T) {
+	archive := testDayOneArchive(t, map[string]string{"photos/a.jpeg": "a"})
+
+	_, err := readDayOneExport(archive)
+	assert.Error(t, err)
+}
+
+func TestDayOneEntryParams(t *testing.T) {
+	archive := testDayOneArchive(t, map[string]string{"Journal.json": testDayOneJournal})
+	journals, err := readDayOneExport(archive)
+	require.NoError(t, err)
+
+	topicIDs := map[string]string{"Travel": "topic-travel", "Summer": "topic-summer"}
+	refs := map[string]AttachmentRef{"PHOTO1": {ID: "attachment-1", Name: "beach.jpeg"}}
+
+	params := dayOneEntryParams(journals[0].Entries[0], topicIDs, refs)
+
+	assert.True(t, params.Bookmarked)
+	assert.Equal(t, mo.Some("topic-travel"), params.TopicID)
+	assert.Equal(t, []string{"Travel", "Summer"}, params.Tags)
+	assert.Equal(t, "Beach day\n\n![](attachment://attachment-1)\n\n![](dayone-moment://MISSING)", params.Content)
+	assert.Equal(t, []AttachmentRef{refs["PHOTO1"]}, params.Attachments)
+	assert.Equal(t, mo.Some(Location{Latitude: 43.7, Longitude: 7.26, PlaceName: "Nice"}), params.Metadata.Location)
+	assert.Equal(t, mo.Some(Weather{TemperatureCelsius: 27.5, Condition: "Sunny", HumidityPercent: 60}), params.Metadata.Weather)
+
+	assert.Equal(t, mo.Some(time.Date(2019, 6, 1, 7, 15, 0, 0, time.UTC)), params.AuthoredAt)
+	require.NoError(t, params.Metadata.Validate())
+
+	untagged := dayOneEntryParams(journals[0].Entries[1], topicIDs, nil)
+	assert.Equal(t, mo.None[string](), untagged.TopicID)
+	assert.False(t, untagged.Bookmarked)
+}
+
+func TestDayOneImportID(t *testing.T) {
+	assert.Equal(t, dayOneImportID("diary-1", "entry", "A1"), dayOneImportID("diary-1", "entry", "A1"))
+	assert.NotEqual(t, dayOneImportID("diary-1", "entry", "A1"), dayOneImportID("diary-2", "entry", "A1"))
+	assert.NotEqual(t, dayOneImportID("diary-1", "entry", "A1"), dayOneImportID("diary-1", "tag", "A1"))
+}
+
+func (s *ClientSuite) TestImportDayOne_Idempotent() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-dayone-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"Journal.json": testDayOneJournal,
+		"photos/0cc175b9c0f1b6a831c399e269772661.jpeg": "a",
+	} {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	export := bytes.NewReader(buf.Bytes())
+
+	// Act: Import, edit what was imported, then import again
+	first, err := s.client.ImportDayOne(ctx, export, export.Size(), DayOneImportParams{})
+	require.NoError(t, err)
+
+	diaryID := first.Diaries["Journal"]
+
+	topic, err := s.client.GetTopicByID(ctx, diaryID, dayOneImportID(diaryID, "tag", "Travel"))
+	require.NoError(t, err)
+	topicParams := topic.PutParams()
+	topicParams.Title = "Trips"
+	topicParams.Color = "#00FF00"
+	_, err = s.client.PutTopic(ctx, diaryID, topic.ID, topicParams)
+	require.NoError(t, err)
+
+	entry, err := s.client.GetEntryByID(ctx, diaryID, dayOneImportID(diaryID, "entry", "F6E5D4C3B2A1"))
+	require.NoError(t, err)
+	entryParams := entry.PutParams()
+	entryParams.Content = "Quiet evening, edited"
+	_, err = s.client.PutEntry(ctx, diaryID, entry.ID, entryParams)
+	require.NoError(t, err)
+
+	second, err := s.client.ImportDayOne(ctx, export, export.Size(), DayOneImportParams{})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, first.Entries)
+	assert.Equal(t, 2, first.Topics)
+	assert.Equal(t, 1, first.Photos)
+	assert.Equal(t, 1, first.MissingPhotos)
+	assert.Equal(t, first.Diaries, second.Diaries)
+
+	assert.Equal(t, 0, second.Entries)
+	assert.Equal(t, 2, second.SkippedEntries)
+	assert.Equal(t, 0, second.Topics)
+	assert.Equal(t, 0, second.Photos)
+
+	entries, err := s.client.GetEntries(ctx, diaryID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	topics, err := s.client.GetTopics(ctx, diaryID)
+	require.NoError(t, err)
+	assert.Len(t, topics, 2)
+
+	gotTopic, err := s.client.GetTopicByID(ctx, diaryID, topic.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Trips", gotTopic.Title)
+	assert.Equal(t, "#00FF00", gotTopic.Color)
+
+	gotEntry, err := s.client.GetEntryByID(ctx, diaryID, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Quiet evening, edited", gotEntry.Content)
+}
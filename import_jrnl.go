@@ -0,0 +1,293 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+)
+
+// jrnlHeaderPattern matches the date line starting a jrnl entry, with or without
+// the brackets newer jrnl versions write
+var jrnlHeaderPattern = regexp.MustCompile(`^\[?(\d{4}-\d{2}-\d{2}[ T]\d{1,2}:\d{2}(?::\d{2})?(?: ?[AaPp][Mm])?)\]?(?:\s+(.*))?$`)
+
+// jrnlTagPattern matches @tags preceded by whitespace or the start of the text
+var jrnlTagPattern = regexp.MustCompile(`(?:^|[\s(])@([\p{L}\p{N}_][\p{L}\p{N}_-]*)`)
+
+// jrnlTimeLayouts are the timestamp formats accepted in jrnl date lines
+var jrnlTimeLayouts = []string{
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 3:04 PM",
+	"2006-01-02 3:04PM",
+	"2006-01-02 3:04:05 PM",
+}
+
+// TextImportParams contains options for the plain text importers
+type TextImportParams struct {
+	// Location is the time zone of timestamps in the input, time.Local when nil
+	Location *time.Location
+
+	// DryRun parses the input and reports the entries without creating them
+	DryRun bool
+}
+
+func (p TextImportParams) location() *time.Location {
+	if p.Location == nil {
+		return time.Local
+	}
+
+	return p.Location
+}
+
+// TextImportReport describes the entries found by a plain text importer
+type TextImportReport struct {
+	DryRun  bool
+	Entries []TextImportEntry
+
+	// Warnings describe input that was skipped
+	Warnings []string
+}
+
+// TextImportEntry is an entry found in plain text input
+type TextImportEntry struct {
+	// Source is the file and line the entry starts at
+	Source  string
+	Date    time.Time
+	Title   string
+	Content string
+	Tags    []string
+	Starred bool
+
+	// EntryID is the ID of the created entry, empty in a dry run
+	EntryID string
+}
+
+// Tags returns the distinct tags of the reported entries, sorted
+func (r *TextImportReport) Tags() []string {
+	var tags []string
+	for _, entry := range r.Entries {
+		for _, tag := range entry.Tags {
+			if !containsTag(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
+
+// jrnlExport is the JSON export written by `jrnl --export json`
+type jrnlExport struct {
+	Entries []struct {
+		Title   string   `json:"title"`
+		Body    string   `json:"body"`
+		Date    string   `json:"date"`
+		Time    string   `json:"time"`
+		Tags    []string `json:"tags"`
+		Starred bool     `json:"starred"`
+	} `json:"entries"`
+}
+
+// ImportJrnl imports a jrnl journal, either its plain text file or its JSON
+// export. Entries starred with * are bookmarked and @tags become entry tags. The
+// time of an entry in the journal becomes its AuthoredAt.
+func (c *Client) ImportJrnl(ctx context.Context, diaryID string, r io.Reader, params TextImportParams) (*TextImportReport, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read journal")
+	}
+
+	var report *TextImportReport
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		report, err = parseJrnlJSON(data, params.location())
+	} else {
+		report, err = parseJrnl(string(data), "journal", params.location())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.importTextEntries(ctx, diaryID, report, params)
+}
+
+// importTextEntries creates the reported entries in chronological order unless
+// the import is a dry run
+func (c *Client) importTextEntries(ctx context.Context, diaryID string, report *TextImportReport, params TextImportParams) (*TextImportReport, error) {
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		return report.Entries[i].Date.Before(report.Entries[j].Date)
+	})
+
+	report.DryRun = params.DryRun
+	if params.DryRun {
+		return report, nil
+	}
+
+	for i := range report.Entries {
+		entry := &report.Entries[i]
+
+		created, err := c.CreateEntry(ctx, diaryID, CreateEntryParams{
+			Content:    entry.Content,
+			Bookmarked: entry.Starred,
+			Tags:       entry.Tags,
+			AuthoredAt: mo.Some(entry.Date),
+		})
+		if err != nil {
+			return report, errors.Wrapf(err, "failed to import entry from %s", entry.Source)
+		}
+
+		entry.EntryID = created.ID
+	}
+
+	return report, nil
+}
+
+// parseJrnl splits a jrnl plain text journal into entries
+func parseJrnl(text, source string, loc *time.Location) (*TextImportReport, error) {
+	report := TextImportReport{}
+
+	var current *TextImportEntry
+	var body []string
+	flush := func() {
+		if current == nil {
+			return
+		}
+
+		content := strings.TrimRight(strings.Join(body, "\n"), "\n\t ")
+		current.Content = content
+		current.Title = entryTitle(content)
+		current.Tags = jrnlTags(content)
+		report.Entries = append(report.Entries, *current)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		raw := strings.TrimRight(scanner.Text(), "\r")
+
+		match := jrnlHeaderPattern.FindStringSubmatch(raw)
+		if match == nil {
+			if current != nil {
+				body = append(body, raw)
+			} else if strings.TrimSpace(raw) != "" {
+				report.Warnings = append(report.Warnings, fmt.Sprintf("%s:%d: text before the first entry skipped", source, line))
+			}
+			continue
+		}
+
+		date, err := parseJrnlTime(match[1], loc)
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s:%d: %v", source, line, err))
+			if current != nil {
+				body = append(body, raw)
+			}
+			continue
+		}
+
+		flush()
+
+		title, starred := jrnlStar(match[2])
+		current = &TextImportEntry{
+			Source:  fmt.Sprintf("%s:%d", source, line),
+			Date:    date,
+			Starred: starred,
+		}
+		body = []string{title}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read journal")
+	}
+
+	flush()
+
+	return &report, nil
+}
+
+// parseJrnlJSON reads a jrnl JSON export
+func parseJrnlJSON(data []byte, loc *time.Location) (*TextImportReport, error) {
+	var export jrnlExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, errors.Wrap(err, "failed to parse jrnl JSON export")
+	}
+
+	report := TextImportReport{}
+	for i, item := range export.Entries {
+		source := fmt.Sprintf("entries[%d]", i)
+
+		date, err := parseJrnlTime(item.Date+" "+item.Time, loc)
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s: %v", source, err))
+			continue
+		}
+
+		content := strings.TrimSpace(item.Title)
+		if body := strings.TrimRight(item.Body, "\n\t "); body != "" {
+			content += "\n" + body
+		}
+
+		tags := make([]string, 0, len(item.Tags))
+		for _, tag := range item.Tags {
+			tags = append(tags, strings.TrimLeft(tag, "@"))
+		}
+
+		report.Entries = append(report.Entries, TextImportEntry{
+			Source:  source,
+			Date:    date,
+			Title:   entryTitle(content),
+			Content: content,
+			Tags:    normalizeTags(tags),
+			Starred: item.Starred,
+		})
+	}
+
+	return &report, nil
+}
+
+func parseJrnlTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.Replace(strings.TrimSpace(value), "T", " ", 1)
+	for _, layout := range jrnlTimeLayouts {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(value), loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.Errorf("invalid date %q", value)
+}
+
+// jrnlStar strips the star jrnl writes at the end of the title line, also
+// accepting it at the start
+func jrnlStar(title string) (string, bool) {
+	title = strings.TrimSpace(title)
+
+	switch {
+	case title == "*":
+		return "", true
+	case strings.HasSuffix(title, " *"):
+		return strings.TrimSpace(strings.TrimSuffix(title, "*")), true
+	case strings.HasPrefix(title, "* "):
+		return strings.TrimSpace(strings.TrimPrefix(title, "*")), true
+	}
+
+	return title, false
+}
+
+// jrnlTags returns the @tags of a text without the @, in order of appearance
+func jrnlTags(text string) []string {
+	var tags []string
+	for _, match := range jrnlTagPattern.FindAllStringSubmatch(text, -1) {
+		tags = append(tags, match[1])
+	}
+
+	return normalizeTags(tags)
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJrnl = `My journal notes
+
+[2024-01-05 09:30] Standup went well. @work
+Talked about the @release-plan with @Work team.
+
+[2024-01-06 21:15] Movie night *
+Watched something fun.
+2024-01-07 07:05 AM Early run @health
+`
+
+func TestParseJrnl(t *testing.T) {
+	report, err := parseJrnl(testJrnl, "journal.txt", time.UTC)
+	require.NoError(t, err)
+
+	require.Len(t, report.Entries, 3)
+	assert.Equal(t, []string{"journal.txt:1: text before the first entry skipped"}, report.Warnings)
+
+	first := report.Entries[0]
+	assert.Equal(t, "journal.txt:3", first.Source)
+	assert.Equal(t, time.Date(2024, 1, 5, 9, 30, 0, 0, time.UTC), first.Date)
+	assert.Equal(t, "Standup went well. @work\nTalked about the @release-plan with @Work team.", first.Content)
+	assert.Equal(t, "Standup went well. @work", first.Title)
+	assert.Equal(t, []string{"work", "release-plan"}, first.Tags)
+	assert.False(t, first.Starred)
+
+	second := report.Entries[1]
+	assert.True(t, second.Starred)
+	assert.Equal(t, "Movie night\nWatched something fun.", second.Content)
+
+	third := report.Entries[2]
+	assert.Equal(t, time.Date(2024, 1, 7, 7, 5, 0, 0, time.UTC), third.Date)
+	assert.Equal(t, []string{"health"}, third.Tags)
+}
+
+func TestParseJrnlJSON(t *testing.T) {
+	data := `{
+  "tags": {"@work": 1},
+  "entries": [
+    {"title": "Standup.", "body": "Went well.\n", "date": "2024-01-05", "time": "09:30", "tags": ["@work"], "starred": true},
+    {"title": "Broken", "body": "", "date": "yesterday", "time": "", "tags": [], "starred": false}
+  ]
+}`
+
+	report, err := parseJrnlJSON([]byte(data), time.UTC)
+	require.NoError(t, err)
+
+	require.Len(t, report.Entries, 1)
+	assert.Equal(t, "Standup.\nWent well.", report.Entries[0].Content)
+	assert.Equal(t, time.Date(2024, 1, 5, 9, 30, 0, 0, time.UTC), report.Entries[0].Date)
+	assert.Equal(t, []string{"work"}, report.Entries[0].Tags)
+	assert.True(t, report.Entries[0].Starred)
+	require.Len(t, report.Warnings, 1)
+	assert.True(t, strings.HasPrefix(report.Warnings[0], "entries[1]:"))
+}
+
+func TestJrnlStar(t *testing.T) {
+	tests := []struct {
+		title   string
+		want    string
+		starred bool
+	}{
+		{"Title *", "Title", true},
+		{"* Title", "Title", true},
+		{"*", "", true},
+		{"Title", "Title", false},
+		{"5*3 is 15", "5*3 is 15", false},
+	}
+
+	for _, tt := range tests {
+		title, starred := jrnlStar(tt.title)
+		assert.Equal(t, tt.want, title, tt.title)
+		assert.Equal(t, tt.starred, starred, tt.title)
+	}
+}
+
+func TestJrnlTags(t *testing.T) {
+	assert.Equal(t, []string{"home", "diy"}, jrnlTags("@home fixing (@diy) mail me@example.com @Home"))
+	assert.Nil(t, jrnlTags("no tags here"))
+}
+
+func TestTextImportReport_Tags(t *testing.T) {
+	report := TextImportReport{Entries: []TextImportEntry{
+		{Tags: []string{"work", "home"}},
+		{Tags: []string{"Work", "health"}},
+	}}
+
+	assert.Equal(t, []string{"health", "home", "work"}, report.Tags())
+}
+
+func (s *ClientSuite) TestImportJrnl_DryRun() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-jrnl-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	// Act
+	dryRun, err := s.client.ImportJrnl(ctx, diary.ID, strings.NewReader(testJrnl), TextImportParams{DryRun: true})
+	require.NoError(t, err)
+
+	afterDryRun, err := s.client.GetEntries(ctx, diary.ID)
+	require.NoError(t, err)
+
+	report, err := s.client.ImportJrnl(ctx, diary.ID, strings.NewReader(testJrnl), TextImportParams{})
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, dryRun.DryRun)
+	assert.Len(t, dryRun.Entries, 3)
+	assert.Empty(t, dryRun.Entries[0].EntryID)
+	assert.Empty(t, afterDryRun)
+
+	require.Len(t, report.Entries, 3)
+	entry, err := s.client.GetEntryByID(ctx, diary.ID, report.Entries[1].EntryID)
+	require.NoError(t, err)
+	assert.True(t, entry.Bookmarked)
+	assert.Equal(t, "Movie night\nWatched something fun.", entry.Content)
+	assert.True(t, report.Entries[1].Date.Equal(entry.EffectiveDate()))
+}
@@ -0,0 +1,388 @@
+package client
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+)
+
+// MarkdownImportResult counts the entities written by ImportMarkdown
+type MarkdownImportResult struct {
+	Entries   int
+	Topics    int
+	Templates int
+
+	// DroppedAttachments lists the attachment references that were removed from
+	// imported entries because the diary does not hold the attachment
+	DroppedAttachments []DroppedAttachment
+}
+
+// DroppedAttachment is an attachment reference removed from an imported entry
+type DroppedAttachment struct {
+	EntryID    string
+	Attachment AttachmentRef
+}
+
+// markdownImport holds the entities read from a Markdown export, topics ordered
+// parents first
+type markdownImport struct {
+	Templates []importedTemplate
+	Topics    []importedTopic
+	Entries   []EntryWrite
+
+	// Created holds the creation times of entries without an authored time, by ID
+	Created map[string]time.Time
+}
+
+type importedTemplate struct {
+	ID     string
+	Params PutTemplateParams
+}
+
+type importedTopic struct {
+	ID     string
+	Params PutTopicParams
+}
+
+// ImportMarkdown reads a directory in the layout written by ExportMarkdown into the
+// diary. Entities keep the IDs from their front matter, so importing an export
+// again updates them instead of creating duplicates. An entry belongs to the topic
+// of the folder it is in, so moving files between folders moves the entries.
+//
+// Files and folders without front matter are imported as well, with IDs derived
+// from their paths. The authored time of an entry becomes its AuthoredAt. Without
+// one, the creation time does, since the server assigns creation times, unless
+// the diary already holds the entry with that creation time.
+//
+// Exports hold attachment references only, and attachments are encrypted per
+// diary, so references to attachments the diary does not hold, e.g. when importing
+// into another diary, are dropped and listed in the result.
+func (c *Client) ImportMarkdown(ctx context.Context, diaryID, dir string) (*MarkdownImportResult, error) {
+	imported, err := readMarkdownExport(diaryID, os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	var result MarkdownImportResult
+
+	if len(imported.Created) > 0 {
+		stored, err := c.getEntries(ctx, diaryID)
+		if err != nil {
+			return &result, errors.Wrap(err, "failed to get entries")
+		}
+
+		createdAt := make(map[string]time.Time, len(stored))
+		for _, entry := range stored {
+			createdAt[entry.Id] = entry.CreatedAt
+		}
+
+		imported.applyCreated(createdAt)
+	}
+
+	result.DroppedAttachments, err = c.dropMissingAttachments(ctx, diaryID, imported.Entries)
+	if err != nil {
+		return &result, err
+	}
+
+	for _, template := range imported.Templates {
+		if _, err := c.PutTemplate(ctx, diaryID, template.ID, template.Params); err != nil {
+			return &result, errors.Wrapf(err, "failed to import template %s", template.ID)
+		}
+		result.Templates++
+	}
+
+	for _, topic := range imported.Topics {
+		if _, err := c.PutTopic(ctx, diaryID, topic.ID, topic.Params); err != nil {
+			return &result, errors.Wrapf(err, "failed to import topic %s", topic.ID)
+		}
+		result.Topics++
+	}
+
+	entries, err := c.PutEntries(ctx, diaryID, imported.Entries)
+	result.Entries = len(entries)
+	if err != nil {
+		return &result, errors.Wrap(err, "failed to import entries")
+	}
+
+	return &result, nil
+}
+
+// dropMissingAttachments removes the references to attachments the diary does not
+// hold, or whose upload never completed, from entries and returns them
+func (c *Client) dropMissingAttachments(ctx context.Context, diaryID string, entries []EntryWrite) ([]DroppedAttachment, error) {
+	available := map[string]bool{}
+
+	var dropped []DroppedAttachment
+	for i := range entries {
+		refs := entries[i].Params.Attachments
+		kept := refs[:0]
+
+		for _, ref := range refs {
+			ok, checked := available[ref.ID]
+			if !checked {
+				attachment, err := c.getAttachment(ctx, diaryID, ref.ID)
+				if err != nil && !errors.Is(err, ErrAttachmentNotFound) {
+					return nil, errors.Wrapf(err, "failed to check attachment %s", ref.ID)
+				}
+
+				ok = err == nil && attachment.Completed
+				available[ref.ID] = ok
+			}
+
+			if !ok {
+				dropped = append(dropped, DroppedAttachment{EntryID: entries[i].ID, Attachment: ref})
+				continue
+			}
+
+			kept = append(kept, ref)
+		}
+
+		entries[i].Params.Attachments = kept
+	}
+
+	return dropped, nil
+}
+
+// applyCreated keeps the creation time of an entry without an authored time as
+// its AuthoredAt, unless storedCreatedAt, the creation times of the entries
+// already in the diary, holds the same time for it. Entries imported into another
+// diary are created anew, while an entry imported back into its diary keeps
+// the same AuthoredAt it had when exported.
+func (m *markdownImport) applyCreated(storedCreatedAt map[string]time.Time) {
+	for i, entry := range m.Entries {
+		created, ok := m.Created[entry.ID]
+		if !ok {
+			continue
+		}
+
+		if stored, ok := storedCreatedAt[entry.ID]; ok && stored.Equal(created) {
+			continue
+		}
+
+		m.Entries[i].Params.AuthoredAt = mo.Some(created)
+	}
+}
+
+// readMarkdownExport reads the entities of a Markdown export from fsys
+func readMarkdownExport(diaryID string, fsys fs.FS) (*markdownImport, error) {
+	imported := markdownImport{Created: map[string]time.Time{}}
+
+	err := walkMarkdown(fsys, markdownTemplatesDir, func(name string) error {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		var frontMatter markdownTemplate
+		body, _, err := decodeMarkdown(data, &frontMatter)
+		if err != nil {
+			return errors.Wrap(err, name)
+		}
+
+		imported.Templates = append(imported.Templates, importedTemplate{
+			ID: orImportID(frontMatter.ID, diaryID, name),
+			Params: PutTemplateParams{
+				Name:        frontMatter.Name,
+				Description: frontMatter.Description,
+				Icon:        frontMatter.Icon,
+				Color:       frontMatter.Color,
+				Content:     body,
+				Prompts:     frontMatter.Prompts,
+			},
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Topic IDs by folder, filled in while walking, which visits parents first
+	folderTopics := map[string]string{}
+
+	err = fs.WalkDir(fsys, markdownTopicsDir, func(name string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() || name == markdownTopicsDir {
+			return nil
+		}
+
+		topic, err := readMarkdownTopic(fsys, diaryID, name)
+		if err != nil {
+			return err
+		}
+
+		if parentID, ok := folderTopics[path.Dir(name)]; ok {
+			topic.Params.ParentID = mo.Some(parentID)
+		}
+
+		folderTopics[name] = topic.ID
+		imported.Topics = append(imported.Topics, *topic)
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read topics")
+	}
+
+	for _, root := range []string{markdownEntriesDir, markdownTopicsDir} {
+		err := walkMarkdown(fsys, root, func(name string) error {
+			if path.Base(name) == markdownTopicFileName {
+				return nil
+			}
+
+			entry, created, err := readMarkdownEntry(fsys, diaryID, name)
+			if err != nil {
+				return err
+			}
+
+			if entry.Params.AuthoredAt.IsAbsent() && !created.IsZero() {
+				imported.Created[entry.ID] = created
+			}
+
+			if topicID, ok := folderTopics[path.Dir(name)]; ok {
+				entry.Params.TopicID = mo.Some(topicID)
+			}
+
+			imported.Entries = append(imported.Entries, *entry)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &imported, nil
+}
+
+// readMarkdownTopic reads the topic of a folder from its _topic.md, a folder
+// without one becomes a topic titled after the folder
+func readMarkdownTopic(fsys fs.FS, diaryID, dir string) (*importedTopic, error) {
+	name := path.Join(dir, markdownTopicFileName)
+
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &importedTopic{
+			ID:     markdownImportID(diaryID, dir),
+			Params: PutTopicParams{Title: path.Base(dir)},
+		}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", name)
+	}
+
+	var frontMatter markdownTopic
+	body, _, err := decodeMarkdown(data, &frontMatter)
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+
+	params := PutTopicParams{
+		Title:       frontMatter.Title,
+		Description: body,
+		Color:       frontMatter.Color,
+	}
+	if params.Title == "" {
+		params.Title = path.Base(dir)
+	}
+	if frontMatter.DefaultTemplate != "" {
+		params.DefaultTemplateID = mo.Some(frontMatter.DefaultTemplate)
+	}
+
+	return &importedTopic{
+		ID:     orImportID(frontMatter.ID, diaryID, dir),
+		Params: params,
+	}, nil
+}
+
+// readMarkdownEntry reads an entry file and returns the creation time from its
+// front matter along with it, see applyCreated
+func readMarkdownEntry(fsys fs.FS, diaryID, name string) (*EntryWrite, time.Time, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrapf(err, "failed to read %s", name)
+	}
+
+	var frontMatter markdownEntry
+	body, _, err := decodeMarkdown(data, &frontMatter)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, name)
+	}
+
+	metadata, err := metadataFromYAML(frontMatter.Metadata)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, name)
+	}
+
+	attachments, err := fromMarkdownAttachments(frontMatter.Attachments)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, name)
+	}
+
+	var authoredAt mo.Option[time.Time]
+	if frontMatter.Authored != nil {
+		authoredAt = mo.Some(*frontMatter.Authored)
+	}
+
+	return &EntryWrite{
+		ID: orImportID(frontMatter.ID, diaryID, name),
+		Params: PutEntryParams{
+			AuthoredAt:    authoredAt,
+			Content:       body,
+			Archived:      frontMatter.Archived,
+			Bookmarked:    frontMatter.Bookmarked,
+			PreviewHidden: frontMatter.PreviewHidden,
+			Attachments:   attachments,
+			Tags:          frontMatter.Tags,
+			Metadata:      metadata,
+		},
+	}, frontMatter.Created, nil
+}
+
+// walkMarkdown calls fn for every Markdown file below root, in lexical order
+func walkMarkdown(fsys fs.FS, root string, fn func(name string) error) error {
+	err := fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(name, ".md") {
+			return nil
+		}
+
+		return fn(name)
+	})
+
+	return errors.Wrapf(err, "failed to read %s", root)
+}
+
+// orImportID returns id, or an ID derived from the file path when it is empty
+func orImportID(id, diaryID, name string) string {
+	if id != "" {
+		return id
+	}
+
+	return markdownImportID(diaryID, name)
+}
+
+// markdownImportID derives a stable ID from a path, so importing the same files
+// again updates the entities created the first time
+func markdownImportID(diaryID, name string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("thingsdiary:markdown:"+diaryID+":"+name)).String()
+}
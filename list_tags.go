@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// TagCount is a tag together with the number of entries carrying it
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// ListTags returns every tag used in a diary with the number of entries carrying it,
+// most used first. Deleted entries are not counted. Tags live in encrypted entry details, so the index is built
+// client-side from all entries. Spellings that differ only in case are counted together
+// under the spelling of the earliest written entry, by effective date.
+func (c *Client) ListTags(ctx context.Context, diaryID string) ([]TagCount, error) {
+	entries, err := c.GetEntries(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return countTags(entries), nil
+}
+
+// countTags counts the tags of entries that are not deleted
+func countTags(entries []*Entry) []TagCount {
+	live := liveOnly(entries, func(e *Entry) bool { return e.DeletedAt.IsPresent() })
+	SortEntries(live)
+
+	counts := make(map[string]*TagCount)
+	for _, entry := range live {
+		for _, tag := range entry.Tags {
+			key := strings.ToLower(tag)
+			if counts[key] == nil {
+				counts[key] = &TagCount{Tag: tag}
+			}
+
+			counts[key].Count++
+		}
+	}
+
+	tags := make([]TagCount, 0, len(counts))
+	for _, count := range counts {
+		tags = append(tags, *count)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+
+		return tags[i].Tag < tags[j].Tag
+	})
+
+	return tags
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountTags(t *testing.T) {
+	now := time.Now()
+
+	entries := []*Entry{
+		{ID: "entry-1", Tags: []string{"Work"}, CreatedAt: now},
+		{ID: "entry-2", Tags: []string{"work", "ideas"}, CreatedAt: now, AuthoredAt: mo.Some(now.Add(-time.Hour))},
+		{ID: "entry-3", Tags: []string{"WORK", "drafts"}, CreatedAt: now, DeletedAt: mo.Some(now)},
+	}
+
+	assert.Equal(t, []TagCount{
+		{Tag: "work", Count: 2},
+		{Tag: "ideas", Count: 1},
+	}, countTags(entries))
+}
+
+func (s *ClientSuite) TestEntry_ListTags() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-list-tags-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	_, err = s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "First", Tags: []string{"work", "ideas"}})
+	require.NoError(t, err)
+
+	_, err = s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Second", Tags: []string{"work"}})
+	require.NoError(t, err)
+
+	_, err = s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Untagged"})
+	require.NoError(t, err)
+
+	deleted, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Deleted", Tags: []string{"work", "drafts"}})
+	require.NoError(t, err)
+
+	err = s.client.DeleteEntry(ctx, diary.ID, deleted.ID)
+	require.NoError(t, err)
+
+	// Act
+	tags, err := s.client.ListTags(ctx, diary.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []TagCount{
+		{Tag: "work", Count: 2},
+		{Tag: "ideas", Count: 1},
+	}, tags)
+}
+
+func (s *ClientSuite) TestEntry_GetEntries_FilterByTags() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-filter-tags-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	both, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Both", Tags: []string{"work", "ideas"}})
+	require.NoError(t, err)
+
+	workOnly, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Work only", Tags: []string{"work"}})
+	require.NoError(t, err)
+
+	_, err = s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Untagged"})
+	require.NoError(t, err)
+
+	// Act
+	workEntries, err := s.client.GetEntries(ctx, diary.ID, GetEntriesParams{Tags: []string{"Work"}})
+	require.NoError(t, err)
+
+	bothEntries, err := s.client.GetEntries(ctx, diary.ID, GetEntriesParams{Tags: []string{"work", "ideas"}})
+	require.NoError(t, err)
+
+	// Assert
+	var workIDs []string
+	for _, entry := range workEntries {
+		workIDs = append(workIDs, entry.ID)
+	}
+	assert.ElementsMatch(t, []string{both.ID, workOnly.ID}, workIDs)
+
+	require.Len(t, bothEntries, 1)
+	assert.Equal(t, both.ID, bothEntries[0].ID)
+}
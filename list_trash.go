@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Trash holds the soft-deleted entities of a diary, most recently deleted first
+type Trash struct {
+	Entries   []*Entry
+	Topics    []*Topic
+	Templates []*Template
+}
+
+// IsEmpty reports whether the trash holds nothing
+func (t *Trash) IsEmpty() bool {
+	return len(t.Entries) == 0 && len(t.Topics) == 0 && len(t.Templates) == 0
+}
+
+// ListTrash returns the deleted entries, topics and templates of a diary
+func (c *Client) ListTrash(ctx context.Context, diaryID string) (*Trash, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	entries, err := c.GetEntries(ctx, diaryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get entries")
+	}
+
+	topics, err := c.GetTopics(ctx, diaryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get topics")
+	}
+
+	templates, err := c.GetTemplates(ctx, diaryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get templates")
+	}
+
+	trash := Trash{
+		Entries:   deletedOnly(entries, func(e *Entry) time.Time { return e.DeletedAt.OrEmpty() }),
+		Topics:    deletedOnly(topics, func(t *Topic) time.Time { return t.DeletedAt.OrEmpty() }),
+		Templates: deletedOnly(templates, func(t *Template) time.Time { return t.DeletedAt.OrEmpty() }),
+	}
+
+	return &trash, nil
+}
+
+// deletedOnly keeps items with a deletion time, most recently deleted first
+func deletedOnly[T any](items []T, deletedAt func(T) time.Time) []T {
+	var deleted []T
+	for _, item := range items {
+		if !deletedAt(item).IsZero() {
+			deleted = append(deleted, item)
+		}
+	}
+
+	sort.SliceStable(deleted, func(i, j int) bool {
+		return deletedAt(deleted[i]).After(deletedAt(deleted[j]))
+	})
+
+	return deleted
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletedOnly(t *testing.T) {
+	now := time.Now()
+	entries := []*Entry{
+		{ID: "live"},
+		{ID: "old", DeletedAt: mo.Some(now.Add(-time.Hour))},
+		{ID: "recent", DeletedAt: mo.Some(now)},
+	}
+
+	deleted := deletedOnly(entries, func(e *Entry) time.Time { return e.DeletedAt.OrEmpty() })
+
+	require.Len(t, deleted, 2)
+	assert.Equal(t, "recent", deleted[0].ID)
+	assert.Equal(t, "old", deleted[1].ID)
+}
+
+func (s *ClientSuite) TestTrash_ListAndRestore() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-trash-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	topic, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Ideas"})
+	require.NoError(t, err)
+
+	template, err := s.client.CreateTemplate(ctx, diary.ID, CreateTemplateParams{Content: "## Today"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Oops"})
+	require.NoError(t, err)
+
+	_, err = s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Keep"})
+	require.NoError(t, err)
+
+	require.NoError(t, s.client.DeleteEntry(ctx, diary.ID, entry.ID))
+	require.NoError(t, s.client.DeleteTopic(ctx, diary.ID, topic.ID))
+	require.NoError(t, s.client.DeleteTemplate(ctx, diary.ID, template.ID))
+
+	// Act
+	trash, err := s.client.ListTrash(ctx, diary.ID)
+	require.NoError(t, err)
+
+	restoredEntry, err := s.client.RestoreEntry(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+
+	restoredTopic, err := s.client.RestoreTopic(ctx, diary.ID, topic.ID)
+	require.NoError(t, err)
+
+	restoredTemplate, err := s.client.RestoreTemplate(ctx, diary.ID, template.ID)
+	require.NoError(t, err)
+
+	emptyTrash, err := s.client.ListTrash(ctx, diary.ID)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, trash.Entries, 1)
+	assert.Equal(t, entry.ID, trash.Entries[0].ID)
+	require.Len(t, trash.Topics, 1)
+	require.Len(t, trash.Templates, 1)
+
+	assert.True(t, restoredEntry.DeletedAt.IsAbsent())
+	assert.Equal(t, "Oops", restoredEntry.Content)
+	assert.Greater(t, restoredEntry.Version, entry.Version)
+	assert.True(t, restoredTopic.DeletedAt.IsAbsent())
+	assert.True(t, restoredTemplate.DeletedAt.IsAbsent())
+	assert.True(t, emptyTrash.IsEmpty())
+}
+
+func (s *ClientSuite) TestTrash_Purge() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-trash-purge-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Gone"})
+	require.NoError(t, err)
+
+	require.NoError(t, s.client.DeleteEntry(ctx, diary.ID, entry.ID))
+
+	// Act: Recently deleted entries survive a threshold, then are purged without one
+	keptCount, err := s.client.PurgeTrash(ctx, diary.ID, 24*time.Hour)
+	require.NoError(t, err)
+
+	purgedCount, err := s.client.PurgeTrash(ctx, diary.ID, 0)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 0, keptCount)
+	assert.Equal(t, 1, purgedCount)
+
+	_, err = s.client.GetEntryByID(ctx, diary.ID, entry.ID)
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+}
@@ -0,0 +1,310 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// LocalStore keeps diaries, their entries, topics and templates together with an
+// outbox of changes not yet sent to the server, so an OfflineClient works without
+// network.
+//
+// Entities are stored as the server returns them and outbox operations as encrypted
+// requests, so content stays encrypted under the diary keys at rest and diary keys
+// stay sealed to the account key. Each diary is persisted as one JSON file when the
+// store is backed by a directory.
+type LocalStore struct {
+	mu      sync.Mutex
+	dir     string
+	diaries map[string]*localDiary
+	loaded  bool
+}
+
+// OutboxOperationKind is the kind of change an outbox operation sends
+type OutboxOperationKind string
+
+const (
+	OutboxPutEntry       OutboxOperationKind = "put_entry"
+	OutboxDeleteEntry    OutboxOperationKind = "delete_entry"
+	OutboxPutTopic       OutboxOperationKind = "put_topic"
+	OutboxDeleteTopic    OutboxOperationKind = "delete_topic"
+	OutboxPutTemplate    OutboxOperationKind = "put_template"
+	OutboxDeleteTemplate OutboxOperationKind = "delete_template"
+)
+
+// OutboxOperation is a change made offline and not yet sent to the server
+type OutboxOperation struct {
+	Kind     OutboxOperationKind `json:"kind"`
+	EntityID string              `json:"entity_id"`
+	QueuedAt time.Time           `json:"queued_at"`
+
+	// Request is the encrypted request of a put operation
+	Entry    *openapi.PutEntryRequest    `json:"entry,omitempty"`
+	Topic    *openapi.PutTopicRequest    `json:"topic,omitempty"`
+	Template *openapi.PutTemplateRequest `json:"template,omitempty"`
+
+	// DeleteEntries is passed on when deleting a topic
+	DeleteEntries bool `json:"delete_entries,omitempty"`
+
+	// Base is the synced version an entry change started from, used to detect and
+	// resolve conflicts. It is nil for an entry created offline.
+	Base *openapi.Entry `json:"base,omitempty"`
+}
+
+// localDiary is the stored state of one diary
+type localDiary struct {
+	Diary     *openapi.Diary               `json:"diary,omitempty"`
+	Keys      []openapi.DiaryEncryptionKey `json:"keys"`
+	Entries   map[string]*openapi.Entry    `json:"entries"`
+	Topics    map[string]*openapi.Topic    `json:"topics"`
+	Templates map[string]*openapi.Template `json:"templates"`
+	Outbox    []OutboxOperation            `json:"outbox,omitempty"`
+	SyncedAt  time.Time                    `json:"synced_at"`
+}
+
+func newLocalDiary() *localDiary {
+	return &localDiary{
+		Entries:   map[string]*openapi.Entry{},
+		Topics:    map[string]*openapi.Topic{},
+		Templates: map[string]*openapi.Template{},
+	}
+}
+
+// enqueue adds an operation to the outbox. Earlier operations on the same entity
+// are dropped, as the newer one supersedes them.
+func (d *localDiary) enqueue(op OutboxOperation) {
+	kept := d.Outbox[:0]
+	for _, queued := range d.Outbox {
+		if queued.EntityID != op.EntityID || outboxEntityType(queued.Kind) != outboxEntityType(op.Kind) {
+			kept = append(kept, queued)
+		}
+	}
+
+	d.Outbox = append(kept, op)
+}
+
+// pending returns the queued operation on the entity
+func (d *localDiary) pending(kind OutboxOperationKind, entityID string) (OutboxOperation, bool) {
+	for _, queued := range d.Outbox {
+		if queued.EntityID == entityID && outboxEntityType(queued.Kind) == outboxEntityType(kind) {
+			return queued, true
+		}
+	}
+
+	return OutboxOperation{}, false
+}
+
+// base returns the synced version of an entry that the first unsent change to it
+// started from, nil for an entry created offline
+func (d *localDiary) base(entryID string) *openapi.Entry {
+	if queued, ok := d.pending(OutboxPutEntry, entryID); ok {
+		return queued.Base
+	}
+
+	return d.Entries[entryID]
+}
+
+// hasPending reports whether the outbox holds an operation on the entity
+func (d *localDiary) hasPending(kind OutboxOperationKind, entityID string) bool {
+	_, ok := d.pending(kind, entityID)
+	return ok
+}
+
+// outboxEntityType returns the type of entity an operation kind changes
+func outboxEntityType(kind OutboxOperationKind) string {
+	switch kind {
+	case OutboxPutEntry, OutboxDeleteEntry:
+		return "entry"
+	case OutboxPutTopic, OutboxDeleteTopic:
+		return "topic"
+	default:
+		return "template"
+	}
+}
+
+// NewLocalStore opens a local store persisted in dir, creating it on first write.
+// An empty dir creates a store kept in memory only.
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{
+		dir:     dir,
+		diaries: map[string]*localDiary{},
+	}
+}
+
+// DiaryIDs returns the IDs of the stored diaries
+func (s *LocalStore) DiaryIDs() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadAll(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(s.diaries))
+	for id := range s.diaries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// Pending returns the outbox operations of a diary in the order they will be sent
+func (s *LocalStore) Pending(diaryID string) ([]OutboxOperation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadAll(); err != nil {
+		return nil, err
+	}
+
+	diary, ok := s.diaries[diaryID]
+	if !ok {
+		return nil, nil
+	}
+
+	return append([]OutboxOperation(nil), diary.Outbox...), nil
+}
+
+// view calls fn with the state of a diary, which fn must not modify
+func (s *LocalStore) view(diaryID string, fn func(d *localDiary) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadAll(); err != nil {
+		return err
+	}
+
+	diary, ok := s.diaries[diaryID]
+	if !ok {
+		return errors.Wrapf(ErrDiaryNotFound, "diary %s is not available offline", diaryID)
+	}
+
+	return fn(diary)
+}
+
+// update calls fn with the state of a diary, created when missing, and persists
+// the changes unless fn fails
+func (s *LocalStore) update(diaryID string, fn func(d *localDiary) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadAll(); err != nil {
+		return err
+	}
+
+	diary, ok := s.diaries[diaryID]
+	if !ok {
+		diary = newLocalDiary()
+	}
+
+	// Work on a copy, so a failing fn leaves the stored state untouched
+	data, err := json.Marshal(diary)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal local diary")
+	}
+
+	working := newLocalDiary()
+	if err := json.Unmarshal(data, working); err != nil {
+		return errors.Wrap(err, "failed to copy local diary")
+	}
+
+	if err := fn(working); err != nil {
+		return err
+	}
+
+	if err := s.save(diaryID, working); err != nil {
+		return err
+	}
+
+	s.diaries[diaryID] = working
+
+	return nil
+}
+
+// remove deletes a diary from the store
+func (s *LocalStore) remove(diaryID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadAll(); err != nil {
+		return err
+	}
+
+	delete(s.diaries, diaryID)
+
+	if s.dir == "" {
+		return nil
+	}
+
+	if err := os.Remove(s.path(diaryID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "failed to remove local diary")
+	}
+
+	return nil
+}
+
+// loadAll reads the stored diaries from disk on first use
+func (s *LocalStore) loadAll() error {
+	if s.loaded || s.dir == "" {
+		return nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return errors.Wrap(err, "failed to list local diaries")
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, "failed to read local diary")
+		}
+
+		diary := newLocalDiary()
+		if err := json.Unmarshal(data, diary); err != nil {
+			return errors.Wrapf(err, "failed to parse local diary %s", filepath.Base(path))
+		}
+
+		diaryID := filepath.Base(path)
+		s.diaries[diaryID[:len(diaryID)-len(".json")]] = diary
+	}
+
+	s.loaded = true
+
+	return nil
+}
+
+// save persists a diary atomically
+func (s *LocalStore) save(diaryID string, diary *localDiary) error {
+	if s.dir == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(diary)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal local diary")
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create local store directory")
+	}
+
+	if err := writeFileAtomically(s.path(diaryID), data); err != nil {
+		return errors.Wrap(err, "failed to save local diary")
+	}
+
+	return nil
+}
+
+func (s *LocalStore) path(diaryID string) string {
+	return filepath.Join(s.dir, filepath.Base(diaryID)+".json")
+}
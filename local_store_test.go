@@ -0,0 +1,112 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+func TestLocalStore_Persists(t *testing.T) {
+	dir := t.TempDir()
+
+	store := NewLocalStore(dir)
+	err := store.update("diary-1", func(d *localDiary) error {
+		d.Entries["entry-1"] = &openapi.Entry{Id: "entry-1", Version: 1}
+		d.enqueue(OutboxOperation{Kind: OutboxPutEntry, EntityID: "entry-1", QueuedAt: time.Now()})
+		return nil
+	})
+	require.NoError(t, err)
+
+	reopened := NewLocalStore(dir)
+	diaryIDs, err := reopened.DiaryIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"diary-1"}, diaryIDs)
+
+	pending, err := reopened.Pending("diary-1")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "entry-1", pending[0].EntityID)
+}
+
+func TestLocalStore_FailedUpdateLeavesStateUntouched(t *testing.T) {
+	store := NewLocalStore("")
+	require.NoError(t, store.update("diary-1", func(d *localDiary) error {
+		d.Entries["entry-1"] = &openapi.Entry{Id: "entry-1", Version: 1}
+		return nil
+	}))
+
+	err := store.update("diary-1", func(d *localDiary) error {
+		d.Entries["entry-1"].Version = 2
+		d.enqueue(OutboxOperation{Kind: OutboxDeleteEntry, EntityID: "entry-1"})
+		return errors.New("boom")
+	})
+	require.Error(t, err)
+
+	err = store.view("diary-1", func(d *localDiary) error {
+		assert.Equal(t, uint64(1), d.Entries["entry-1"].Version)
+		assert.Empty(t, d.Outbox)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestLocalDiary_EnqueueSupersedes(t *testing.T) {
+	d := newLocalDiary()
+	d.enqueue(OutboxOperation{Kind: OutboxPutEntry, EntityID: "a"})
+	d.enqueue(OutboxOperation{Kind: OutboxPutTopic, EntityID: "a"})
+	d.enqueue(OutboxOperation{Kind: OutboxPutEntry, EntityID: "b"})
+	d.enqueue(OutboxOperation{Kind: OutboxDeleteEntry, EntityID: "a"})
+
+	require.Len(t, d.Outbox, 3)
+	assert.Equal(t, OutboxPutTopic, d.Outbox[0].Kind)
+	assert.Equal(t, "b", d.Outbox[1].EntityID)
+	assert.Equal(t, OutboxDeleteEntry, d.Outbox[2].Kind)
+	assert.True(t, d.hasPending(OutboxPutEntry, "a"))
+	assert.False(t, d.hasPending(OutboxPutTemplate, "a"))
+}
+
+func TestLocalDiary_BaseSurvivesDelete(t *testing.T) {
+	d := newLocalDiary()
+	synced := &openapi.Entry{Id: "entry-1", Version: 1}
+	d.Entries["entry-1"] = synced
+
+	d.enqueue(OutboxOperation{Kind: OutboxDeleteEntry, EntityID: "entry-1", Base: d.base("entry-1")})
+	d.Entries["entry-1"] = &openapi.Entry{Id: "entry-1", Version: 2}
+
+	assert.Same(t, synced, d.base("entry-1"))
+	assert.Nil(t, d.base("entry-2"))
+}
+
+func TestMergeRemote(t *testing.T) {
+	local := map[string]*openapi.Entry{
+		"newer-remote": {Id: "newer-remote", Version: 1},
+		"older-remote": {Id: "older-remote", Version: 5},
+		"pending":      {Id: "pending", Version: 1},
+		"purged":       {Id: "purged", Version: 1},
+		"pending-new":  {Id: "pending-new", Version: 1},
+	}
+	remote := []*openapi.Entry{
+		{Id: "newer-remote", Version: 2},
+		{Id: "older-remote", Version: 4},
+		{Id: "pending", Version: 9},
+		{Id: "added", Version: 1},
+	}
+
+	mergeRemote(local, remote,
+		func(e *openapi.Entry) string { return e.Id },
+		func(e *openapi.Entry) uint64 { return e.Version },
+		func(id string) bool { return id == "pending" || id == "pending-new" },
+	)
+
+	assert.Equal(t, uint64(2), local["newer-remote"].Version)
+	assert.Equal(t, uint64(5), local["older-remote"].Version)
+	assert.Equal(t, uint64(1), local["pending"].Version)
+	assert.Contains(t, local, "pending-new")
+	assert.Contains(t, local, "added")
+	assert.NotContains(t, local, "purged")
+}
@@ -0,0 +1,244 @@
+package client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Layout of a Markdown export
+const (
+	markdownIndexName     = "index.md"
+	markdownEntriesDir    = "entries"
+	markdownTopicsDir     = "topics"
+	markdownTemplatesDir  = "templates"
+	markdownTopicFileName = "_topic.md"
+)
+
+// maxSlugLength bounds the length of file and folder names derived from titles
+const maxSlugLength = 40
+
+// markdownFrontMatterDelimiter separates YAML front matter from the Markdown body
+const markdownFrontMatterDelimiter = "---\n"
+
+// markdownEntry is the front matter of an exported entry
+type markdownEntry struct {
+	ID            string               `yaml:"id"`
+	Created       time.Time            `yaml:"created"`
+	Updated       time.Time            `yaml:"updated"`
+	Authored      *time.Time           `yaml:"authored,omitempty"`
+	Topic         string               `yaml:"topic,omitempty"`
+	Bookmarked    bool                 `yaml:"bookmarked"`
+	Archived      bool                 `yaml:"archived"`
+	PreviewHidden bool                 `yaml:"preview_hidden,omitempty"`
+	Tags          []string             `yaml:"tags,omitempty"`
+	Metadata      map[string]any       `yaml:"metadata,omitempty"`
+	Attachments   []markdownAttachment `yaml:"attachments,omitempty"`
+}
+
+// markdownAttachment is an attachment reference in entry front matter. Only the
+// reference is exported, the content stays on the server.
+type markdownAttachment struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	MimeType    string `yaml:"mime_type"`
+	Size        int64  `yaml:"size"`
+	ContentHash string `yaml:"content_hash"`
+}
+
+// markdownTopic is the front matter of an exported topic, its body is the description
+type markdownTopic struct {
+	ID              string `yaml:"id"`
+	Title           string `yaml:"title"`
+	Color           string `yaml:"color,omitempty"`
+	DefaultTemplate string `yaml:"default_template,omitempty"`
+}
+
+// markdownTemplate is the front matter of an exported template, its body is the content
+type markdownTemplate struct {
+	ID          string   `yaml:"id"`
+	Name        string   `yaml:"name,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Icon        string   `yaml:"icon,omitempty"`
+	Color       string   `yaml:"color,omitempty"`
+	Prompts     []string `yaml:"prompts,omitempty"`
+}
+
+// encodeMarkdown writes front matter followed by a blank line and the body
+func encodeMarkdown(frontMatter any, body string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(markdownFrontMatterDelimiter)
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(frontMatter); err != nil {
+		return nil, errors.Wrap(err, "failed to encode front matter")
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to encode front matter")
+	}
+
+	buf.WriteString(markdownFrontMatterDelimiter)
+	buf.WriteString("\n")
+	buf.WriteString(body)
+
+	return buf.Bytes(), nil
+}
+
+// decodeMarkdown splits a Markdown file into front matter, decoded into
+// frontMatter, and body. Files without front matter are all body.
+func decodeMarkdown(data []byte, frontMatter any) (body string, hasFrontMatter bool, err error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, markdownFrontMatterDelimiter) {
+		return text, false, nil
+	}
+
+	rest := text[len(markdownFrontMatterDelimiter):]
+
+	var header string
+	if strings.HasPrefix(rest, markdownFrontMatterDelimiter) {
+		header, body = "", rest[len(markdownFrontMatterDelimiter):]
+	} else {
+		end := strings.Index(rest, "\n"+markdownFrontMatterDelimiter)
+		if end < 0 {
+			return "", false, errors.New("front matter is not terminated")
+		}
+
+		header, body = rest[:end+1], rest[end+1+len(markdownFrontMatterDelimiter):]
+	}
+
+	if err := yaml.Unmarshal([]byte(header), frontMatter); err != nil {
+		return "", false, errors.Wrap(err, "failed to parse front matter")
+	}
+
+	// encodeMarkdown separates the body with a blank line
+	body = strings.TrimPrefix(body, "\n")
+
+	return body, true, nil
+}
+
+// metadataToYAML converts entry metadata into a generic value encoded as YAML
+func metadataToYAML(metadata EntryMetadata) (map[string]any, error) {
+	if metadata.IsZero() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal metadata")
+	}
+
+	var value map[string]any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, errors.Wrap(err, "failed to convert metadata")
+	}
+
+	return value, nil
+}
+
+// metadataFromYAML converts metadata decoded from YAML back into entry metadata
+func metadataFromYAML(value map[string]any) (EntryMetadata, error) {
+	var metadata EntryMetadata
+	if len(value) == 0 {
+		return metadata, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return metadata, errors.Wrap(err, "failed to convert metadata")
+	}
+
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return metadata, errors.Wrap(err, "failed to parse metadata")
+	}
+
+	return metadata, nil
+}
+
+func toMarkdownAttachments(refs []AttachmentRef) []markdownAttachment {
+	var attachments []markdownAttachment
+	for _, ref := range refs {
+		attachments = append(attachments, markdownAttachment{
+			ID:          ref.ID,
+			Name:        ref.Name,
+			MimeType:    ref.MimeType,
+			Size:        ref.Size,
+			ContentHash: hex.EncodeToString(ref.ContentHash),
+		})
+	}
+
+	return attachments
+}
+
+func fromMarkdownAttachments(attachments []markdownAttachment) ([]AttachmentRef, error) {
+	var refs []AttachmentRef
+	for _, attachment := range attachments {
+		hash, err := hex.DecodeString(attachment.ContentHash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid content hash of attachment %s", attachment.ID)
+		}
+
+		refs = append(refs, AttachmentRef{
+			ID:          attachment.ID,
+			Name:        attachment.Name,
+			MimeType:    attachment.MimeType,
+			Size:        attachment.Size,
+			ContentHash: hash,
+		})
+	}
+
+	return refs, nil
+}
+
+// slugify turns a title into a lowercase file name made of letters, digits and
+// dashes
+func slugify(title string) string {
+	var b strings.Builder
+	dash, length := false, 0
+	for _, r := range strings.ToLower(title) {
+		if length == maxSlugLength {
+			break
+		}
+
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteRune('-')
+				length++
+			}
+			b.WriteRune(r)
+			length++
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	return b.String()
+}
+
+// entryTitle returns the first non-empty line of an entry without heading markers
+func entryTitle(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(strings.TrimLeft(line, "# ")); line != "" {
+			return line
+		}
+	}
+
+	return ""
+}
+
+// shortID returns the prefix of an ID used to keep file names unique
+func shortID(id string) string {
+	id = strings.ReplaceAll(id, "-", "")
+	if len(id) > 8 {
+		return id[:8]
+	}
+
+	return id
+}
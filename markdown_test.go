@@ -0,0 +1,418 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+func testMarkdownDiary() (*Diary, []*Topic, []*Template, []*Entry) {
+	created := time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)
+
+	diary := &Diary{ID: "diary-1", Title: "Personal Diary", Description: "Everyday notes"}
+	topics := []*Topic{
+		{ID: "topic-work", Title: "Work", Color: "#ff0000", Description: "Job related"},
+		{ID: "topic-meetings", Title: "Meetings", ParentID: mo.Some("topic-work"), DefaultTemplateID: mo.Some("template-1")},
+		{ID: "topic-gone", Title: "Gone", DeletedAt: mo.Some(created)},
+	}
+	templates := []*Template{
+		{ID: "template-1", Name: "Standup", Content: "## Yesterday\n", Prompts: []string{"What did you do?"}},
+	}
+	entries := []*Entry{
+		{
+			ID:         "entry-2",
+			Content:    "# Standup\n\nShipped the export.\n",
+			TopicID:    mo.Some("topic-meetings"),
+			Bookmarked: true,
+			Tags:       []string{"work"},
+			Metadata:   EntryMetadata{SchemaVersion: EntryMetadataSchemaVersion, Mood: mo.Some(Mood{Score: 4, Label: "calm"})},
+			Attachments: []AttachmentRef{
+				{ID: "attachment-1", Name: "photo.jpg", MimeType: "image/jpeg", Size: 42, ContentHash: []byte{0xab, 0xcd}},
+			},
+			CreatedAt: created.Add(time.Hour),
+			UpdatedAt: created.Add(2 * time.Hour),
+		},
+		{
+			ID:         "entry-1",
+			Content:    "No topic, no trailing newline",
+			AuthoredAt: mo.Some(created.Add(-48 * time.Hour)),
+			Archived:   true,
+			CreatedAt:  created,
+			UpdatedAt:  created,
+		},
+		{ID: "entry-deleted", Content: "Deleted", CreatedAt: created, DeletedAt: mo.Some(created)},
+	}
+
+	return diary, topics, templates, entries
+}
+
+func TestMarkdownExport_Deterministic(t *testing.T) {
+	diary, topics, templates, entries := testMarkdownDiary()
+
+	first, err := renderMarkdownExport(diary, topics, templates, entries)
+	require.NoError(t, err)
+
+	// Input order must not matter
+	topics[0], topics[1] = topics[1], topics[0]
+	entries[0], entries[1] = entries[1], entries[0]
+
+	second, err := renderMarkdownExport(diary, topics, templates, entries)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Contains(t, first, "index.md")
+	assert.Contains(t, first, "topics/work/_topic.md")
+	assert.Contains(t, first, "topics/work/meetings/_topic.md")
+	assert.Contains(t, first, "topics/work/meetings/2025-03-01-standup-entry2.md")
+	assert.Contains(t, first, "entries/2025-02-27-no-topic-no-trailing-newline-entry1.md")
+	assert.Contains(t, first, "templates/standup-template.md")
+	assert.Len(t, first, 6)
+}
+
+func TestMarkdownExport_RoundTrip(t *testing.T) {
+	diary, topics, templates, entries := testMarkdownDiary()
+
+	files, err := renderMarkdownExport(diary, topics, templates, entries)
+	require.NoError(t, err)
+
+	fsys := fstest.MapFS{}
+	for name, data := range files {
+		fsys[name] = &fstest.MapFile{Data: data}
+	}
+
+	imported, err := readMarkdownExport(diary.ID, fsys)
+	require.NoError(t, err)
+
+	require.Len(t, imported.Templates, 1)
+	assert.Equal(t, "template-1", imported.Templates[0].ID)
+	assert.Equal(t, templates[0].Content, imported.Templates[0].Params.Content)
+	assert.Equal(t, templates[0].Prompts, imported.Templates[0].Params.Prompts)
+
+	require.Len(t, imported.Topics, 2)
+	assert.Equal(t, "topic-work", imported.Topics[0].ID)
+	assert.Equal(t, "Job related", imported.Topics[0].Params.Description)
+	assert.Equal(t, "#ff0000", imported.Topics[0].Params.Color)
+	assert.Equal(t, "topic-meetings", imported.Topics[1].ID)
+	assert.Equal(t, mo.Some("topic-work"), imported.Topics[1].Params.ParentID)
+	assert.Equal(t, mo.Some("template-1"), imported.Topics[1].Params.DefaultTemplateID)
+
+	// Imported into a diary that does not hold the entries
+	imported.applyCreated(nil)
+
+	require.Len(t, imported.Entries, 2)
+	byID := map[string]PutEntryParams{}
+	for _, entry := range imported.Entries {
+		byID[entry.ID] = entry.Params
+	}
+
+	for _, entry := range entries[:2] {
+		params := byID[entry.ID]
+		assert.Equal(t, entry.Content, params.Content)
+		assert.Equal(t, entry.TopicID, params.TopicID)
+		assert.Equal(t, entry.Bookmarked, params.Bookmarked)
+		assert.Equal(t, entry.Archived, params.Archived)
+		assert.Equal(t, entry.Tags, params.Tags)
+		assert.Equal(t, entry.Attachments, params.Attachments)
+		assert.Equal(t, entry.Metadata.Mood, params.Metadata.Mood)
+		assert.Equal(t, mo.Some(entry.EffectiveDate()), params.AuthoredAt)
+	}
+}
+
+func TestMarkdownExport_RoundTripSameDiary(t *testing.T) {
+	diary, topics, templates, entries := testMarkdownDiary()
+
+	exported, err := renderMarkdownExport(diary, topics, templates, entries)
+	require.NoError(t, err)
+
+	fsys := fstest.MapFS{}
+	for name, data := range exported {
+		fsys[name] = &fstest.MapFile{Data: data}
+	}
+
+	imported, err := readMarkdownExport(diary.ID, fsys)
+	require.NoError(t, err)
+
+	// Imported back into the diary, which keeps the server creation times
+	stored := map[string]*Entry{}
+	createdAt := map[string]time.Time{}
+	for _, entry := range entries {
+		stored[entry.ID] = entry
+		createdAt[entry.ID] = entry.CreatedAt
+	}
+	imported.applyCreated(createdAt)
+
+	reimported := make([]*Entry, 0, len(imported.Entries))
+	for _, write := range imported.Entries {
+		if write.ID == "entry-2" {
+			assert.Equal(t, mo.None[time.Time](), write.Params.AuthoredAt)
+		}
+
+		original := stored[write.ID]
+		reimported = append(reimported, &Entry{
+			ID:            write.ID,
+			DiaryID:       diary.ID,
+			Content:       write.Params.Content,
+			TopicID:       write.Params.TopicID,
+			Archived:      write.Params.Archived,
+			Bookmarked:    write.Params.Bookmarked,
+			PreviewHidden: write.Params.PreviewHidden,
+			Attachments:   write.Params.Attachments,
+			Tags:          write.Params.Tags,
+			Metadata:      write.Params.Metadata,
+			AuthoredAt:    write.Params.AuthoredAt,
+			CreatedAt:     original.CreatedAt,
+			UpdatedAt:     original.UpdatedAt,
+		})
+	}
+
+	reexported, err := renderMarkdownExport(diary, topics, templates, reimported)
+	require.NoError(t, err)
+
+	for name, data := range exported {
+		assert.Equal(t, string(data), string(reexported[name]), name)
+	}
+	assert.Len(t, reexported, len(exported))
+}
+
+func TestReadMarkdownExport_PlainFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"topics/Travel/italy.md": {Data: []byte("Rome was great\n")},
+		"entries/note.md":        {Data: []byte("Just a note")},
+		"README.md":              {Data: []byte("ignored")},
+	}
+
+	first, err := readMarkdownExport("diary-1", fsys)
+	require.NoError(t, err)
+
+	second, err := readMarkdownExport("diary-1", fsys)
+	require.NoError(t, err)
+
+	require.Len(t, first.Topics, 1)
+	assert.Equal(t, "Travel", first.Topics[0].Params.Title)
+
+	require.Len(t, first.Entries, 2)
+	assert.Equal(t, "Just a note", first.Entries[0].Params.Content)
+	assert.Equal(t, mo.None[string](), first.Entries[0].Params.TopicID)
+	assert.Equal(t, mo.Some(first.Topics[0].ID), first.Entries[1].Params.TopicID)
+
+	// IDs derived from paths are stable across imports
+	assert.Equal(t, first, second)
+}
+
+func TestDecodeMarkdown(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		body           string
+		hasFrontMatter bool
+		wantErr        bool
+	}{
+		{name: "no front matter", data: "Hello\n", body: "Hello\n"},
+		{name: "front matter", data: "---\nid: x\n---\n\nHello", body: "Hello", hasFrontMatter: true},
+		{name: "no blank line", data: "---\nid: x\n---\nHello", body: "Hello", hasFrontMatter: true},
+		{name: "empty front matter", data: "---\n---\nHello", body: "Hello", hasFrontMatter: true},
+		{name: "crlf", data: "---\r\nid: x\r\n---\r\n\r\nHello\r\n", body: "Hello\n", hasFrontMatter: true},
+		{name: "unterminated", data: "---\nid: x\nHello", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var frontMatter markdownEntry
+			body, hasFrontMatter, err := decodeMarkdown([]byte(tt.data), &frontMatter)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, body)
+			assert.Equal(t, tt.hasFrontMatter, hasFrontMatter)
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "hello-world", slugify("  Hello, World! "))
+	assert.Equal(t, "привет-мир", slugify("Привет мир"))
+	assert.Equal(t, "", slugify("!!!"))
+	assert.LessOrEqual(t, len([]rune(slugify("a very long title that keeps going on and on and on"))), maxSlugLength)
+}
+
+func TestWriteMarkdownExport_RemovesStaleFiles(t *testing.T) {
+	dir := t.TempDir()
+	ids := map[string]bool{"topic-old": true, "entry-old": true, "entry-kept": true}
+
+	require.NoError(t, writeMarkdownExport(dir, map[string][]byte{
+		"index.md":              []byte("# Diary"),
+		"topics/old/_topic.md":  []byte("---\nid: topic-old\n---\n"),
+		"entries/old-entry.md":  []byte("---\nid: entry-old\n---\n"),
+		"entries/kept-entry.md": []byte("---\nid: entry-kept\n---\n"),
+	}, ids))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "entries", "notes.txt"), []byte("mine"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "entries", "notes.md"), []byte("mine"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "entries", "other-diary.md"), []byte("---\nid: entry-other\n---\n"), 0o644))
+
+	require.NoError(t, writeMarkdownExport(dir, map[string][]byte{
+		"index.md":              []byte("# Diary"),
+		"entries/kept-entry.md": []byte("---\nid: entry-kept\n---\n"),
+	}, ids))
+
+	assert.NoDirExists(t, filepath.Join(dir, "topics"))
+	assert.NoFileExists(t, filepath.Join(dir, "entries", "old-entry.md"))
+	assert.FileExists(t, filepath.Join(dir, "entries", "kept-entry.md"))
+	assert.FileExists(t, filepath.Join(dir, "entries", "notes.txt"))
+	assert.FileExists(t, filepath.Join(dir, "entries", "notes.md"))
+	assert.FileExists(t, filepath.Join(dir, "entries", "other-diary.md"))
+}
+
+func TestDropMissingAttachments(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		id := filepath.Base(r.URL.Path)
+		if id == "attachment-missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openapi.GetAttachmentResponse{
+			Attachment: openapi.Attachment{
+				Id:          id,
+				ChunkSize:   openapi.MinAttachmentChunkSize,
+				ChunkCount:  1,
+				Completed:   id == "attachment-stored",
+				NoncePrefix: make([]byte, streamNoncePrefixSize),
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	client.authToken = "token"
+
+	stored := AttachmentRef{ID: "attachment-stored", Name: "stored.txt"}
+	missing := AttachmentRef{ID: "attachment-missing", Name: "missing.txt"}
+	incomplete := AttachmentRef{ID: "attachment-incomplete", Name: "incomplete.txt"}
+
+	entries := []EntryWrite{
+		{ID: "entry-1", Params: PutEntryParams{Attachments: []AttachmentRef{stored, missing}}},
+		{ID: "entry-2", Params: PutEntryParams{Attachments: []AttachmentRef{missing, incomplete}}},
+	}
+
+	dropped, err := client.dropMissingAttachments(context.Background(), "diary-1", entries)
+	require.NoError(t, err)
+
+	assert.Equal(t, []AttachmentRef{stored}, entries[0].Params.Attachments)
+	assert.Empty(t, entries[1].Params.Attachments)
+	assert.Equal(t, []DroppedAttachment{
+		{EntryID: "entry-1", Attachment: missing},
+		{EntryID: "entry-2", Attachment: missing},
+		{EntryID: "entry-2", Attachment: incomplete},
+	}, dropped)
+	assert.Equal(t, 3, requests)
+}
+
+func (s *ClientSuite) TestMarkdown_ExportImport() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-markdown-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	topic, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Work"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Shipped it", TopicID: mo.Some(topic.ID), Bookmarked: true})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, s.client.ExportMarkdown(ctx, diary.ID, dir))
+
+	_, err = s.client.PutEntry(ctx, diary.ID, entry.ID, PutEntryParams{Content: "Overwritten"})
+	require.NoError(t, err)
+
+	// Act
+	result, err := s.client.ImportMarkdown(ctx, diary.ID, dir)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Entries)
+	assert.Equal(t, 1, result.Topics)
+
+	imported, err := s.client.GetEntryByID(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Shipped it", imported.Content)
+	assert.True(t, imported.Bookmarked)
+	assert.Equal(t, mo.Some(topic.ID), imported.TopicID)
+	assert.True(t, imported.AuthoredAt.IsAbsent())
+
+	entries, err := s.client.GetEntries(ctx, diary.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func (s *ClientSuite) TestMarkdown_ImportIntoOtherDiaryDropsAttachments() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-markdown-attachments-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	src, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Source"})
+	require.NoError(t, err)
+
+	dst, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Destination"})
+	require.NoError(t, err)
+
+	ref, err := s.client.UploadAttachment(ctx, src.ID, bytes.NewReader([]byte("photo")), UploadAttachmentParams{Name: "photo.jpg"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, src.ID, CreateEntryParams{Content: "Beach", Attachments: []AttachmentRef{*ref}})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, s.client.ExportMarkdown(ctx, src.ID, dir))
+
+	// Act
+	result, err := s.client.ImportMarkdown(ctx, dst.ID, dir)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Entries)
+	require.Len(t, result.DroppedAttachments, 1)
+	assert.Equal(t, entry.ID, result.DroppedAttachments[0].EntryID)
+	assert.Equal(t, ref.ID, result.DroppedAttachments[0].Attachment.ID)
+
+	imported, err := s.client.GetEntryByID(ctx, dst.ID, entry.ID)
+	require.NoError(t, err)
+	assert.Empty(t, imported.Attachments)
+}
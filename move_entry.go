@@ -0,0 +1,118 @@
+package client
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+)
+
+// MoveEntry moves an entry to another topic, or to another diary when dstDiaryID
+// differs from srcDiaryID. An absent dstTopicID moves the entry out of any topic.
+//
+// Within a diary the entry keeps its ID. Across diaries the entry is re-encrypted
+// under the destination diary key and created there with a new ID, attachments are
+// copied into the destination diary, and the source entry is deleted only after
+// the copy succeeded. When that deletion fails the copy is returned along with an
+// error wrapping ErrPartialMove, and the source entry has to be deleted again.
+func (c *Client) MoveEntry(
+	ctx context.Context,
+	srcDiaryID, entryID string,
+	dstDiaryID string,
+	dstTopicID mo.Option[string],
+) (*Entry, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	entry, err := c.GetEntryByID(ctx, srcDiaryID, entryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get entry")
+	}
+
+	return c.moveEntry(ctx, entry, dstDiaryID, dstTopicID)
+}
+
+// MoveTopicEntries moves every entry of a topic with MoveEntry. On failure the
+// entries moved so far are returned along with the error, including a copy whose
+// source entry could not be deleted.
+func (c *Client) MoveTopicEntries(
+	ctx context.Context,
+	srcDiaryID, topicID string,
+	dstDiaryID string,
+	dstTopicID mo.Option[string],
+) ([]*Entry, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	entries, err := c.GetEntries(ctx, srcDiaryID, GetEntriesParams{TopicID: mo.Some(topicID)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get topic entries")
+	}
+
+	moved := make([]*Entry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.DeletedAt.IsPresent() {
+			continue
+		}
+
+		movedEntry, err := c.moveEntry(ctx, entry, dstDiaryID, dstTopicID)
+		if movedEntry != nil {
+			moved = append(moved, movedEntry)
+		}
+		if err != nil {
+			return moved, errors.Wrapf(err, "failed to move entry %s", entry.ID)
+		}
+	}
+
+	return moved, nil
+}
+
+func (c *Client) moveEntry(ctx context.Context, entry *Entry, dstDiaryID string, dstTopicID mo.Option[string]) (*Entry, error) {
+	params := entry.PutParams()
+	params.TopicID = dstTopicID
+
+	if dstDiaryID == entry.DiaryID {
+		return c.PutEntry(ctx, dstDiaryID, entry.ID, params)
+	}
+
+	// The entry is created anew in the destination diary, keep when it was written
+	params.AuthoredAt = mo.Some(entry.EffectiveDate())
+
+	// Attachments are encrypted under the source diary key, so copy them
+	for i, ref := range params.Attachments {
+		copied, err := c.copyAttachment(ctx, entry.DiaryID, dstDiaryID, ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to copy attachment %s", ref.ID)
+		}
+
+		params.Attachments[i] = *copied
+	}
+
+	moved, err := c.PutEntry(ctx, dstDiaryID, uuid.NewString(), params)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create entry in destination diary")
+	}
+
+	if err := c.DeleteEntry(ctx, entry.DiaryID, entry.ID); err != nil {
+		return moved, errors.Wrapf(ErrPartialMove, "moved to %s: %v", moved.ID, err)
+	}
+
+	return moved, nil
+}
+
+// copyAttachment downloads an attachment and uploads it into another diary
+func (c *Client) copyAttachment(ctx context.Context, srcDiaryID, dstDiaryID string, ref AttachmentRef) (*AttachmentRef, error) {
+	var content bytes.Buffer
+	if err := c.DownloadAttachment(ctx, srcDiaryID, ref, &content); err != nil {
+		return nil, err
+	}
+
+	return c.UploadAttachment(ctx, dstDiaryID, bytes.NewReader(content.Bytes()), UploadAttachmentParams{
+		Name:     ref.Name,
+		MimeType: ref.MimeType,
+	})
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestEntry_MoveEntry_Topic() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-move-entry-topic-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	topic, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Travel"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Packing list", Tags: []string{"trip"}})
+	require.NoError(t, err)
+
+	// Act
+	moved, err := s.client.MoveEntry(ctx, diary.ID, entry.ID, diary.ID, mo.Some(topic.ID))
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, entry.ID, moved.ID)
+	assert.Equal(t, mo.Some(topic.ID), moved.TopicID)
+	assert.Equal(t, "Packing list", moved.Content)
+	assert.Equal(t, []string{"trip"}, moved.Tags)
+}
+
+func (s *ClientSuite) TestEntry_MoveEntry_Diary() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-move-entry-diary-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	src, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Source"})
+	require.NoError(t, err)
+
+	dst, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Destination"})
+	require.NoError(t, err)
+
+	content := []byte("attachment content")
+	ref, err := s.client.UploadAttachment(ctx, src.ID, bytes.NewReader(content), UploadAttachmentParams{Name: "note.txt"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, src.ID, CreateEntryParams{
+		Content:     "Moving out",
+		Attachments: []AttachmentRef{*ref},
+	})
+	require.NoError(t, err)
+
+	// Act
+	moved, err := s.client.MoveEntry(ctx, src.ID, entry.ID, dst.ID, mo.None[string]())
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, dst.ID, moved.DiaryID)
+	assert.Equal(t, "Moving out", moved.Content)
+
+	source, err := s.client.GetEntryByID(ctx, src.ID, entry.ID)
+	require.NoError(t, err)
+	assert.True(t, source.DeletedAt.IsPresent())
+
+	require.Len(t, moved.Attachments, 1)
+	var downloaded bytes.Buffer
+	err = s.client.DownloadAttachment(ctx, dst.ID, moved.Attachments[0], &downloaded)
+	require.NoError(t, err)
+	assert.Equal(t, content, downloaded.Bytes())
+}
+
+func (s *ClientSuite) TestEntry_MoveTopicEntries() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-move-topic-entries-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	from, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "From"})
+	require.NoError(t, err)
+
+	to, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "To"})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{
+			Content: fmt.Sprintf("Entry %d", i),
+			TopicID: mo.Some(from.ID),
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Stays"})
+	require.NoError(t, err)
+
+	// Act
+	moved, err := s.client.MoveTopicEntries(ctx, diary.ID, from.ID, diary.ID, mo.Some(to.ID))
+	require.NoError(t, err)
+
+	// Assert
+	assert.Len(t, moved, 3)
+
+	remaining, err := s.client.GetEntries(ctx, diary.ID, GetEntriesParams{TopicID: mo.Some(from.ID)})
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+
+	inTarget, err := s.client.GetEntries(ctx, diary.ID, GetEntriesParams{TopicID: mo.Some(to.ID)})
+	require.NoError(t, err)
+	assert.Len(t, inTarget, 3)
+}
+
+func (s *ClientSuite) TestEntry_MoveEntry_SourceNotDeleted() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: A viewer reads the source diary but cannot delete from it
+	var login = fmt.Sprintf("test-move-entry-partial-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	memberClient, memberCredentials := s.registerMember(ctx, "test-move-entry-partial-member")
+
+	src, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Shared"})
+	require.NoError(t, err)
+
+	topic, err := s.client.CreateTopic(ctx, src.ID, CreateTopicParams{Title: "Recipes"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, src.ID, CreateEntryParams{Content: "Pancakes", TopicID: mo.Some(topic.ID)})
+	require.NoError(t, err)
+
+	_, err = s.client.CreateEntry(ctx, src.ID, CreateEntryParams{Content: "Waffles", TopicID: mo.Some(topic.ID)})
+	require.NoError(t, err)
+
+	_, err = s.client.ShareDiary(ctx, src.ID, memberCredentials.EncryptionPublicKey, DiaryRoleViewer)
+	require.NoError(t, err)
+
+	dst, err := memberClient.CreateDiary(ctx, CreateDiaryParams{Title: "Own"})
+	require.NoError(t, err)
+
+	// Act
+	moved, moveErr := memberClient.MoveEntry(ctx, src.ID, entry.ID, dst.ID, mo.None[string]())
+	movedTopic, moveTopicErr := memberClient.MoveTopicEntries(ctx, src.ID, topic.ID, dst.ID, mo.None[string]())
+
+	// Assert: The copy is reported along with the partial move
+	require.ErrorIs(t, moveErr, ErrPartialMove)
+	require.NotNil(t, moved)
+	assert.Equal(t, dst.ID, moved.DiaryID)
+	assert.Equal(t, "Pancakes", moved.Content)
+
+	require.ErrorIs(t, moveTopicErr, ErrPartialMove)
+	assert.Len(t, movedTopic, 1)
+
+	source, err := s.client.GetEntryByID(ctx, src.ID, entry.ID)
+	require.NoError(t, err)
+	assert.True(t, source.DeletedAt.IsAbsent())
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// OfflineClient reads and writes diaries through a LocalStore, so it keeps working
+// without network. Writes are queued in the outbox of the store and sent by Sync,
+// which also pulls remote changes.
+//
+// The Client must be authenticated, as content is encrypted and decrypted locally
+// with the account keys. Diaries become available offline after the first Sync.
+type OfflineClient struct {
+	client *Client
+	store  *LocalStore
+
+	// resolve handles entries changed both offline and remotely
+	resolve EntryResolver
+}
+
+type offlineOption func(o *OfflineClient)
+
+// WithEntryResolver sets how Sync resolves entries changed both offline and
+// remotely. The default is LastWriterWins.
+func WithEntryResolver(resolve EntryResolver) offlineOption {
+	return func(o *OfflineClient) {
+		o.resolve = resolve
+	}
+}
+
+// NewOfflineClient creates an offline client that syncs store through client
+func NewOfflineClient(client *Client, store *LocalStore, opts ...offlineOption) *OfflineClient {
+	offline := OfflineClient{
+		client:  client,
+		store:   store,
+		resolve: LastWriterWins,
+	}
+
+	for _, opt := range opts {
+		opt(&offline)
+	}
+
+	return &offline
+}
+
+// Store returns the local store of the client
+func (o *OfflineClient) Store() *LocalStore {
+	return o.store
+}
+
+// GetDiaries returns the diaries available offline
+func (o *OfflineClient) GetDiaries(ctx context.Context) ([]*Diary, error) {
+	if o.client.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	diaryIDs, err := o.store.DiaryIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	diaries := make([]*Diary, 0, len(diaryIDs))
+	for _, diaryID := range diaryIDs {
+		err := o.store.view(diaryID, func(d *localDiary) error {
+			if d.Diary == nil {
+				return nil
+			}
+
+			diary, err := o.client.decryptDiary(d.Diary)
+			if err != nil {
+				return err
+			}
+
+			diaries = append(diaries, diary)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return diaries, nil
+}
+
+// localKeyring decrypts the stored keys of a diary
+func (o *OfflineClient) localKeyring(d *localDiary) (*diaryKeyring, error) {
+	if d.Diary == nil || len(d.Keys) == 0 {
+		return nil, errors.Wrap(ErrDiaryNotFound, "diary is not available offline, run Sync first")
+	}
+
+	return o.client.newDiaryKeyring(d.Keys)
+}
+
+// localActiveKey returns the stored active key of a diary
+func (o *OfflineClient) localActiveKey(d *localDiary) (*openapi.DiaryEncryptionKey, error) {
+	if d.Diary == nil || len(d.Keys) == 0 {
+		return nil, errors.Wrap(ErrDiaryNotFound, "diary is not available offline, run Sync first")
+	}
+
+	return activeDiaryKey(d.Keys)
+}
+
+// decryptLocal decrypts stored entities with the keys they reference, ordered by
+// creation time and ID
+func decryptLocal[A any, T any](
+	keyring *diaryKeyring,
+	items map[string]*A,
+	keyID func(*A) string,
+	decrypt func(*A, []byte) (*T, error),
+	order func(*T) (int64, string),
+) ([]*T, error) {
+	result := make([]*T, 0, len(items))
+	for _, item := range items {
+		diaryKey, err := keyring.get(keyID(item))
+		if err != nil {
+			return nil, err
+		}
+
+		decrypted, err := decrypt(item, diaryKey)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, decrypted)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		ti, idi := order(result[i])
+		tj, idj := order(result[j])
+		if ti != tj {
+			return ti < tj
+		}
+
+		return idi < idj
+	})
+
+	return result, nil
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// PutEntry creates or updates an entry locally and queues the change for Sync
+func (o *OfflineClient) PutEntry(ctx context.Context, diaryID, entryID string, params PutEntryParams) (*Entry, error) {
+	if o.client.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	if err := params.Metadata.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid entry metadata")
+	}
+
+	var entry *Entry
+	err := o.store.update(diaryID, func(d *localDiary) error {
+		key, err := o.localActiveKey(d)
+		if err != nil {
+			return err
+		}
+
+		request, diaryKey, err := o.client.buildPutEntryRequest(key, params)
+		if err != nil {
+			return err
+		}
+
+		base := d.base(entryID)
+
+		now := time.Now().UTC()
+		apiEntry := entryFromPutRequest(diaryID, entryID, request, now)
+		if existing, ok := d.Entries[entryID]; ok {
+			apiEntry.CreatedAt = existing.CreatedAt
+		}
+
+		d.Entries[entryID] = apiEntry
+		d.enqueue(OutboxOperation{Kind: OutboxPutEntry, EntityID: entryID, QueuedAt: now, Entry: request, Base: base})
+
+		entry, err = o.client.decryptEntry(apiEntry, diaryKey)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// DeleteEntry deletes an entry locally and queues the deletion for Sync
+func (o *OfflineClient) DeleteEntry(ctx context.Context, diaryID, entryID string) error {
+	if o.client.credentials == nil {
+		return ErrUnauthorized
+	}
+
+	return o.store.update(diaryID, func(d *localDiary) error {
+		entry, ok := d.Entries[entryID]
+		if !ok {
+			return ErrEntryNotFound
+		}
+
+		// Kept so that a later change still knows the version it started from
+		base := d.base(entryID)
+
+		now := time.Now().UTC()
+		entry.DeletedAt = mo.Some(now)
+		entry.UpdatedAt = now
+
+		d.enqueue(OutboxOperation{Kind: OutboxDeleteEntry, EntityID: entryID, QueuedAt: now, Base: base})
+
+		return nil
+	})
+}
+
+// GetEntries returns the locally stored entries of a diary, oldest first
+func (o *OfflineClient) GetEntries(ctx context.Context, diaryID string, params ...GetEntriesParams) ([]*Entry, error) {
+	if o.client.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	var entries []*Entry
+	err := o.store.view(diaryID, func(d *localDiary) error {
+		keyring, err := o.localKeyring(d)
+		if err != nil {
+			return err
+		}
+
+		all, err := decryptLocal(
+			keyring,
+			d.Entries,
+			func(e *openapi.Entry) string { return e.Encryption.DiaryKeyId },
+			o.client.decryptEntry,
+			func(e *Entry) (int64, string) { return e.EffectiveDate().UnixNano(), e.ID },
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range all {
+			if matchesAll(params, entry) {
+				entries = append(entries, entry)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetEntryByID returns a locally stored entry
+func (o *OfflineClient) GetEntryByID(ctx context.Context, diaryID, entryID string) (*Entry, error) {
+	if o.client.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	var entry *Entry
+	err := o.store.view(diaryID, func(d *localDiary) error {
+		apiEntry, ok := d.Entries[entryID]
+		if !ok {
+			return ErrEntryNotFound
+		}
+
+		keyring, err := o.localKeyring(d)
+		if err != nil {
+			return err
+		}
+
+		diaryKey, err := keyring.get(apiEntry.Encryption.DiaryKeyId)
+		if err != nil {
+			return err
+		}
+
+		entry, err = o.client.decryptEntry(apiEntry, diaryKey)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// entryFromPutRequest returns the entry a put request stores, as the server would
+func entryFromPutRequest(diaryID, entryID string, request *openapi.PutEntryRequest, now time.Time) *openapi.Entry {
+	return &openapi.Entry{
+		Id:         entryID,
+		DiaryId:    diaryID,
+		TopicId:    request.TopicId,
+		Encryption: request.Encryption,
+		Details:    request.Details,
+		Version:    request.Version,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/mo"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// PutTemplate creates or updates a template locally and queues the change for Sync
+func (o *OfflineClient) PutTemplate(ctx context.Context, diaryID, templateID string, params PutTemplateParams) (*Template, error) {
+	if o.client.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	var template *Template
+	err := o.store.update(diaryID, func(d *localDiary) error {
+		key, err := o.localActiveKey(d)
+		if err != nil {
+			return err
+		}
+
+		request, diaryKey, err := o.client.buildPutTemplateRequest(key, params)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		apiTemplate := &openapi.Template{
+			Id:         templateID,
+			DiaryId:    diaryID,
+			Encryption: request.Encryption,
+			Details:    request.Details,
+			Version:    request.Version,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+
+		if existing, ok := d.Templates[templateID]; ok {
+			apiTemplate.CreatedAt = existing.CreatedAt
+		}
+
+		d.Templates[templateID] = apiTemplate
+		d.enqueue(OutboxOperation{Kind: OutboxPutTemplate, EntityID: templateID, QueuedAt: now, Template: request})
+
+		template, err = o.client.decryptTemplate(apiTemplate, diaryKey)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteTemplate deletes a template locally and queues the deletion for Sync
+func (o *OfflineClient) DeleteTemplate(ctx context.Context, diaryID, templateID string) error {
+	if o.client.credentials == nil {
+		return ErrUnauthorized
+	}
+
+	return o.store.update(diaryID, func(d *localDiary) error {
+		template, ok := d.Templates[templateID]
+		if !ok {
+			return ErrTemplateNotFound
+		}
+
+		now := time.Now().UTC()
+		template.DeletedAt = mo.Some(now)
+		template.UpdatedAt = now
+
+		d.enqueue(OutboxOperation{Kind: OutboxDeleteTemplate, EntityID: templateID, QueuedAt: now})
+
+		return nil
+	})
+}
+
+// GetTemplates returns the locally stored templates of a diary sorted by name
+func (o *OfflineClient) GetTemplates(ctx context.Context, diaryID string) ([]*Template, error) {
+	if o.client.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	var templates []*Template
+	err := o.store.view(diaryID, func(d *localDiary) error {
+		keyring, err := o.localKeyring(d)
+		if err != nil {
+			return err
+		}
+
+		templates, err = decryptLocal(
+			keyring,
+			d.Templates,
+			func(t *openapi.Template) string { return t.Encryption.DiaryKeyId },
+			o.client.decryptTemplate,
+			func(t *Template) (int64, string) { return t.CreatedAt.UnixNano(), t.ID },
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortTemplatesByName(templates)
+
+	return templates, nil
+}
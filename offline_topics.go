@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// PutTopic creates or updates a topic locally and queues the change for Sync
+func (o *OfflineClient) PutTopic(ctx context.Context, diaryID, topicID string, params PutTopicParams) (*Topic, error) {
+	if o.client.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	var topic *Topic
+	err := o.store.update(diaryID, func(d *localDiary) error {
+		key, err := o.localActiveKey(d)
+		if err != nil {
+			return err
+		}
+
+		if parentID, ok := params.ParentID.Get(); ok {
+			topics, err := o.localTopics(d)
+			if err != nil {
+				return err
+			}
+
+			if err := checkTopicParentIn(topics, topicID, parentID); err != nil {
+				return err
+			}
+		}
+
+		request, diaryKey, err := o.client.buildPutTopicRequest(key, params)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		apiTopic := &openapi.Topic{
+			Id:                topicID,
+			DiaryId:           diaryID,
+			DefaultTemplateId: request.DefaultTemplateId,
+			Encryption:        request.Encryption,
+			Details:           request.Details,
+			Version:           request.Version,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		}
+
+		if existing, ok := d.Topics[topicID]; ok {
+			apiTopic.CreatedAt = existing.CreatedAt
+		}
+
+		d.Topics[topicID] = apiTopic
+		d.enqueue(OutboxOperation{Kind: OutboxPutTopic, EntityID: topicID, QueuedAt: now, Topic: request})
+
+		topic, err = o.client.decryptTopic(apiTopic, diaryKey)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return topic, nil
+}
+
+// DeleteTopic deletes a topic locally and queues the deletion for Sync.
+// Only DeleteEntries of the params is supported offline; nested topics are left
+// in place and show up as top-level topics.
+func (o *OfflineClient) DeleteTopic(ctx context.Context, diaryID, topicID string, params ...DeleteTopicParams) error {
+	if o.client.credentials == nil {
+		return ErrUnauthorized
+	}
+
+	var p DeleteTopicParams
+	if len(params) > 0 {
+		p = params[0]
+	}
+
+	if p.DeleteChildren {
+		return errors.New("deleting nested topics is not supported offline")
+	}
+
+	return o.store.update(diaryID, func(d *localDiary) error {
+		topic, ok := d.Topics[topicID]
+		if !ok {
+			return ErrTopicNotFound
+		}
+
+		now := time.Now().UTC()
+		topic.DeletedAt = mo.Some(now)
+		topic.UpdatedAt = now
+
+		if p.DeleteEntries {
+			for _, entry := range d.Entries {
+				if entry.TopicId.OrEmpty() == topicID && entry.DeletedAt.IsAbsent() {
+					entry.DeletedAt = mo.Some(now)
+					entry.UpdatedAt = now
+				}
+			}
+		}
+
+		d.enqueue(OutboxOperation{Kind: OutboxDeleteTopic, EntityID: topicID, QueuedAt: now, DeleteEntries: p.DeleteEntries})
+
+		return nil
+	})
+}
+
+// GetTopics returns the locally stored topics of a diary, oldest first
+func (o *OfflineClient) GetTopics(ctx context.Context, diaryID string) ([]*Topic, error) {
+	if o.client.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	var topics []*Topic
+	err := o.store.view(diaryID, func(d *localDiary) error {
+		var err error
+		topics, err = o.localTopics(d)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+// localTopics decrypts the stored topics of a diary
+func (o *OfflineClient) localTopics(d *localDiary) ([]*Topic, error) {
+	keyring, err := o.localKeyring(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return decryptLocal(
+		keyring,
+		d.Topics,
+		func(t *openapi.Topic) string { return t.Encryption.DiaryKeyId },
+		o.client.decryptTopic,
+		func(t *Topic) (int64, string) { return t.CreatedAt.UnixNano(), t.ID },
+	)
+}
@@ -19,11 +19,20 @@ const (
 	Rotating DiaryKeyStatus = "rotating"
 )
 
+// Defines values for DiaryMemberRole.
+const (
+	Editor DiaryMemberRole = "editor"
+	Owner  DiaryMemberRole = "owner"
+	Viewer DiaryMemberRole = "viewer"
+)
+
 // Defines values for ResponseErrorCode.
 const (
 	ResponseErrorCodeAccountAlreadyExists ResponseErrorCode = "ACCOUNT_ALREADY_EXISTS"
 	ResponseErrorCodeAccountNotFound      ResponseErrorCode = "ACCOUNT_NOT_FOUND"
+	ResponseErrorCodeAttachmentNotFound   ResponseErrorCode = "ATTACHMENT_NOT_FOUND"
 	ResponseErrorCodeBadRequest           ResponseErrorCode = "BAD_REQUEST"
+	ResponseErrorCodeDeviceNotFound       ResponseErrorCode = "DEVICE_NOT_FOUND"
 	ResponseErrorCodeDiaryKeyNotFound     ResponseErrorCode = "DIARY_KEY_NOT_FOUND"
 	ResponseErrorCodeDiaryLimitExceeded   ResponseErrorCode = "DIARY_LIMIT_EXCEEDED"
 	ResponseErrorCodeDiaryNotFound        ResponseErrorCode = "DIARY_NOT_FOUND"
@@ -32,6 +41,8 @@ const (
 	ResponseErrorCodeInternalServerError  ResponseErrorCode = "INTERNAL_SERVER_ERROR"
 	ResponseErrorCodeInvalidCredentials   ResponseErrorCode = "INVALID_CREDENTIALS"
 	ResponseErrorCodeInvalidSignature     ResponseErrorCode = "INVALID_SIGNATURE"
+	ResponseErrorCodeMemberAlreadyExists  ResponseErrorCode = "MEMBER_ALREADY_EXISTS"
+	ResponseErrorCodeMemberNotFound       ResponseErrorCode = "MEMBER_NOT_FOUND"
 	ResponseErrorCodeRateLimitExceeded    ResponseErrorCode = "RATE_LIMIT_EXCEEDED"
 	ResponseErrorCodeTemplateNotFound     ResponseErrorCode = "TEMPLATE_NOT_FOUND"
 	ResponseErrorCodeTopicNotFound        ResponseErrorCode = "TOPIC_NOT_FOUND"
@@ -41,6 +52,106 @@ const (
 	ResponseErrorCodeVersionTooLow        ResponseErrorCode = "VERSION_TOO_LOW"
 )
 
+// AccountID Unique identifier for an account
+type AccountID = string
+
+// Attachment An encrypted file stored as a sequence of independently encrypted chunks
+type Attachment struct {
+	// ChunkCount Number of chunks of a completed upload (0 while the upload is in progress)
+	ChunkCount int `json:"chunk_count"`
+
+	// ChunkSize Size of a plaintext chunk in bytes
+	ChunkSize int `json:"chunk_size"`
+
+	// Completed Whether every chunk was uploaded and the upload was finalized
+	Completed bool `json:"completed"`
+
+	// ContentId Keyed hash of the plaintext used for deduplication within a diary (<base64_encoded>)
+	ContentId []byte `json:"content_id,omitempty"`
+
+	// CreatedAt Timestamp when the attachment was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// DiaryId Unique identifier for a diary
+	DiaryId DiaryID `json:"diary_id"`
+
+	// Encryption Encryption metadata for diary content
+	Encryption DiaryEncryption `json:"encryption"`
+
+	// Id Unique identifier for an attachment
+	Id AttachmentID `json:"id"`
+
+	// NoncePrefix Random prefix of the per-chunk nonces (<base64_encoded>)
+	NoncePrefix []byte `json:"nonce_prefix"`
+
+	// UploadedChunks Indexes of the chunks stored so far
+	UploadedChunks []int `json:"uploaded_chunks"`
+}
+
+// AttachmentID Unique identifier for an attachment
+type AttachmentID = string
+
+// AuthorizeDeviceRequest Request to enroll a device with its own key pair
+type AuthorizeDeviceRequest struct {
+	// DeviceKeySignature Signature of the device public keys made with the account's signing key (<base64_encoded>)
+	DeviceKeySignature []byte `json:"device_key_signature"`
+
+	// EncryptionPublicKey Device public key for data encryption (<base64_encoded>)
+	EncryptionPublicKey []byte `json:"encryption_public_key"`
+
+	// Name Human readable device name
+	Name string `json:"name"`
+
+	// SignaturePublicKey Device public key for signature verification (<base64_encoded>)
+	SignaturePublicKey []byte `json:"signature_public_key"`
+}
+
+// AuthorizeDeviceResponse defines model for AuthorizeDeviceResponse.
+type AuthorizeDeviceResponse struct {
+	// Device A device enrolled with its own key pair
+	Device Device `json:"device"`
+}
+
+// ChangePasswordRequest Request to change the password of the authenticated account
+type ChangePasswordRequest struct {
+	// NewPassword New user password
+	NewPassword string `json:"new_password"`
+
+	// OldPassword Current user password
+	OldPassword string `json:"old_password"`
+}
+
+// CompleteAttachmentRequest Request to finalize an attachment upload
+type CompleteAttachmentRequest struct {
+	// ChunkCount Total number of uploaded chunks
+	ChunkCount int `json:"chunk_count"`
+
+	// ContentId Keyed hash of the plaintext used for deduplication within a diary (<base64_encoded>)
+	ContentId []byte `json:"content_id,omitempty"`
+}
+
+// CreateAttachmentRequest Request to start an attachment upload
+type CreateAttachmentRequest struct {
+	// ChunkSize Size of a plaintext chunk in bytes
+	ChunkSize int `json:"chunk_size"`
+
+	// ContentId Keyed hash of the plaintext. When a completed attachment with the same
+	// content ID exists in the diary it is returned instead of creating a new one (<base64_encoded>)
+	ContentId []byte `json:"content_id,omitempty"`
+
+	// Encryption Encryption metadata for diary content
+	Encryption DiaryEncryption `json:"encryption"`
+
+	// NoncePrefix Random prefix of the per-chunk nonces (<base64_encoded>)
+	NoncePrefix []byte `json:"nonce_prefix"`
+}
+
+// CreateAttachmentResponse defines model for CreateAttachmentResponse.
+type CreateAttachmentResponse struct {
+	// Attachment An encrypted file stored as a sequence of independently encrypted chunks
+	Attachment Attachment `json:"attachment"`
+}
+
 // CreateDiaryRequest Request to create a new diary
 type CreateDiaryRequest struct {
 	// Details Container for encrypted data with nonce
@@ -65,6 +176,54 @@ type CreateDiaryResponse struct {
 	Diary Diary `json:"diary"`
 }
 
+// Device A device enrolled with its own key pair
+type Device struct {
+	// CreatedAt When the device was enrolled
+	CreatedAt time.Time `json:"created_at"`
+
+	// EncryptionPublicKey Device public key for data encryption (<base64_encoded>)
+	EncryptionPublicKey []byte `json:"encryption_public_key"`
+
+	// Id Unique identifier for a device
+	Id DeviceID `json:"id"`
+
+	// LastSeenAt When the device last authenticated (null if never)
+	LastSeenAt mo.Option[time.Time] `json:"last_seen_at"`
+
+	// Name Human readable device name
+	Name string `json:"name"`
+
+	// SignaturePublicKey Device public key for signature verification (<base64_encoded>)
+	SignaturePublicKey []byte `json:"signature_public_key"`
+}
+
+// DeviceDiaryKeyEnvelope Diary key encrypted for a device
+type DeviceDiaryKeyEnvelope struct {
+	// DiaryId Unique identifier for a diary
+	DiaryId DiaryID `json:"diary_id"`
+
+	// DiaryKeyId Unique identifier for a diary encryption key
+	DiaryKeyId DiaryKeyID `json:"diary_key_id"`
+
+	// Value Diary key encrypted with the device's public key (envelope encryption) (<base64_encoded>)
+	Value []byte `json:"value"`
+}
+
+// DeviceID Unique identifier for a device
+type DeviceID = string
+
+// DeviceLoginVerifyRequest Request to verify login challenge signed with a device key
+type DeviceLoginVerifyRequest struct {
+	// ChallengeId Challenge identifier from login response
+	ChallengeId string `json:"challenge_id"`
+
+	// DeviceId Unique identifier for a device
+	DeviceId DeviceID `json:"device_id"`
+
+	// SignedNonce Cryptographic signature of the challenge nonce made with the device signing key (<base64_encoded>)
+	SignedNonce []byte `json:"signed_nonce"`
+}
+
 // Diary A diary containing encrypted entries, topics, and templates
 type Diary struct {
 	// CreatedAt Timestamp when the diary was created
@@ -125,9 +284,48 @@ type DiaryID = string
 // DiaryKeyID Unique identifier for a diary encryption key
 type DiaryKeyID = string
 
+// DiaryKeyEnvelope Existing diary key encrypted for a new member
+type DiaryKeyEnvelope struct {
+	// DiaryKeyId Unique identifier for a diary encryption key
+	DiaryKeyId DiaryKeyID `json:"diary_key_id"`
+
+	// Value Diary key encrypted with the member's public key (envelope encryption) (<base64_encoded>)
+	Value []byte `json:"value"`
+}
+
+// DiaryKeyRecipient New diary key encrypted for one of the diary members
+type DiaryKeyRecipient struct {
+	// EncryptionPublicKey Public key of the member the diary key is encrypted for (<base64_encoded>)
+	EncryptionPublicKey []byte `json:"encryption_public_key"`
+
+	// Value Diary key encrypted with the member's public key (envelope encryption) (<base64_encoded>)
+	Value []byte `json:"value"`
+}
+
 // DiaryKeyStatus Status of the encryption key
 type DiaryKeyStatus string
 
+// DiaryMember An account with access to a diary
+type DiaryMember struct {
+	// AccountId Unique identifier for an account
+	AccountId AccountID `json:"account_id"`
+
+	// CreatedAt When the member was granted access to the diary
+	CreatedAt time.Time `json:"created_at"`
+
+	// EncryptionPublicKey Member's public key for data encryption (<base64_encoded>)
+	EncryptionPublicKey []byte `json:"encryption_public_key"`
+
+	// Role Role of a diary member
+	Role DiaryMemberRole `json:"role"`
+
+	// SignaturePublicKey Member's public key for signature verification (<base64_encoded>)
+	SignaturePublicKey []byte `json:"signature_public_key"`
+}
+
+// DiaryMemberRole Role of a diary member
+type DiaryMemberRole string
+
 // EncryptedData Container for encrypted data with nonce
 type EncryptedData struct {
 	// Data Encrypted data payload (<base64_encoded>)
@@ -182,6 +380,12 @@ type ErrorResponse struct {
 	ErrorReason mo.Option[string] `json:"error_reason"`
 }
 
+// GetAttachmentResponse defines model for GetAttachmentResponse.
+type GetAttachmentResponse struct {
+	// Attachment An encrypted file stored as a sequence of independently encrypted chunks
+	Attachment Attachment `json:"attachment"`
+}
+
 // GetDiariesResponse defines model for GetDiariesResponse.
 type GetDiariesResponse struct {
 	// Diaries Array of diary objects
@@ -191,12 +395,24 @@ type GetDiariesResponse struct {
 	NextPageToken mo.Option[string] `json:"next_page_token"`
 }
 
+// GetDevicesResponse defines model for GetDevicesResponse.
+type GetDevicesResponse struct {
+	// Devices Devices enrolled for the account
+	Devices []*Device `json:"devices"`
+}
+
 // GetDiaryKeysResponse Response containing diary encryption keys
 type GetDiaryKeysResponse struct {
 	// Keys List of encryption keys sorted by creation date (newest first)
 	Keys []DiaryEncryptionKey `json:"keys"`
 }
 
+// GetDiaryMembersResponse defines model for GetDiaryMembersResponse.
+type GetDiaryMembersResponse struct {
+	// Members Accounts with access to the diary, including the owner
+	Members []*DiaryMember `json:"members"`
+}
+
 // GetDiaryResponse defines model for GetDiaryResponse.
 type GetDiaryResponse struct {
 	// Diary A diary containing encrypted entries, topics, and templates
@@ -281,6 +497,21 @@ type LoginVerifyResponse struct {
 	Token string `json:"token"`
 }
 
+// PutDeviceKeysRequest Request to store diary keys encrypted for a device
+type PutDeviceKeysRequest struct {
+	// EncryptedDiaryKeys Diary keys encrypted with the device's public key
+	EncryptedDiaryKeys []DeviceDiaryKeyEnvelope `json:"encrypted_diary_keys"`
+}
+
+// PutDiaryKeyEnvelopesRequest Request to store diary keys encrypted for the account's next encryption key
+type PutDiaryKeyEnvelopesRequest struct {
+	// EncryptedDiaryKeys Every diary key encrypted with the new public key
+	EncryptedDiaryKeys []DiaryKeyEnvelope `json:"encrypted_diary_keys"`
+
+	// EncryptionPublicKey Public key the diary keys are encrypted for (<base64_encoded>)
+	EncryptionPublicKey []byte `json:"encryption_public_key"`
+}
+
 // PutDiaryRequest Request to update an existing diary
 type PutDiaryRequest struct {
 	// Details Container for encrypted data with nonce
@@ -374,6 +605,63 @@ type PutTopicResponse struct {
 	Topic Topic `json:"topic"`
 }
 
+// RotateAccountKeysRequest Request to replace the account's encryption and signature keys
+type RotateAccountKeysRequest struct {
+	// EncryptionPublicKey New public key for data encryption (<base64_encoded>)
+	EncryptionPublicKey []byte `json:"encryption_public_key"`
+
+	// SignaturePublicKey New public key for signature verification (<base64_encoded>)
+	SignaturePublicKey []byte `json:"signature_public_key"`
+
+	// SignatureProof Signature made with the new signing key proving its possession (<base64_encoded>)
+	SignatureProof []byte `json:"signature_proof"`
+}
+
+// RotateDiaryKeyRequest Request to replace the active diary key
+type RotateDiaryKeyRequest struct {
+	// EncryptedDiaryKeys New diary key encrypted for every diary member
+	EncryptedDiaryKeys []DiaryKeyRecipient `json:"encrypted_diary_keys"`
+}
+
+// RotateDiaryKeyResponse defines model for RotateDiaryKeyResponse.
+type RotateDiaryKeyResponse struct {
+	// Key Encryption key for diary content access
+	Key DiaryEncryptionKey `json:"key"`
+}
+
+// RecoveryRequest Request to start account recovery with the seed phrase
+type RecoveryRequest struct {
+	// Login User login identifier (email or username)
+	Login string `json:"login"`
+}
+
+// RecoveryResponse defines model for RecoveryResponse.
+type RecoveryResponse struct {
+	// ChallengeId Unique identifier for the recovery challenge
+	ChallengeId string `json:"challenge_id"`
+
+	// Nonce Random nonce for challenge signing (<base64_encoded>)
+	Nonce []byte `json:"nonce"`
+}
+
+// RecoveryVerifyRequest Request to reset the password by proving possession of the seed phrase
+type RecoveryVerifyRequest struct {
+	// ChallengeId Challenge identifier from recovery response
+	ChallengeId string `json:"challenge_id"`
+
+	// NewPassword New user password
+	NewPassword string `json:"new_password"`
+
+	// SignedNonce Cryptographic signature of the challenge nonce (<base64_encoded>)
+	SignedNonce []byte `json:"signed_nonce"`
+}
+
+// RecoveryVerifyResponse defines model for RecoveryVerifyResponse.
+type RecoveryVerifyResponse struct {
+	// Token JWT authentication token for API access
+	Token string `json:"token"`
+}
+
 // RegisterRequest Request to register a new user account
 type RegisterRequest struct {
 	// EncryptionPublicKey Public key for data encryption (<base64_encoded>)
@@ -401,6 +689,24 @@ type Response = map[string]interface{}
 // ResponseErrorCode Error codes for API responses
 type ResponseErrorCode string
 
+// ShareDiaryRequest Request to grant another account access to a diary
+type ShareDiaryRequest struct {
+	// EncryptedDiaryKeys Every diary key encrypted with the new member's public key
+	EncryptedDiaryKeys []DiaryKeyEnvelope `json:"encrypted_diary_keys"`
+
+	// EncryptionPublicKey Public key of the account the diary is shared with (<base64_encoded>)
+	EncryptionPublicKey []byte `json:"encryption_public_key"`
+
+	// Role Role of a diary member
+	Role DiaryMemberRole `json:"role"`
+}
+
+// ShareDiaryResponse defines model for ShareDiaryResponse.
+type ShareDiaryResponse struct {
+	// Member An account with access to a diary
+	Member DiaryMember `json:"member"`
+}
+
 // Template A reusable template for creating structured diary entries
 type Template struct {
 	// CreatedAt Timestamp when the template was created
@@ -473,6 +779,20 @@ type TopicID = string
 // XSignature defines model for X-Signature.
 type XSignature = []byte
 
+// AuthorizeDeviceParams defines parameters for AuthorizeDevice.
+type AuthorizeDeviceParams struct {
+	// XSignature Ed25519 signature of the request body, base64 encoded.
+	// Generated using the account's private signing key.
+	XSignature XSignature `json:"X-Signature"`
+}
+
+// PutDeviceKeysParams defines parameters for PutDeviceKeys.
+type PutDeviceKeysParams struct {
+	// XSignature Ed25519 signature of the request body, base64 encoded.
+	// Generated using the account's private signing key.
+	XSignature XSignature `json:"X-Signature"`
+}
+
 // GetDiariesParams defines parameters for GetDiaries.
 type GetDiariesParams struct {
 	// NextPageToken Token for pagination to retrieve the next page of results
@@ -492,6 +812,34 @@ type GetDiaryByIdParams struct {
 	NextPageToken *string `form:"next_page_token,omitempty" json:"next_page_token,omitempty"`
 }
 
+// RotateAccountKeysParams defines parameters for RotateAccountKeys.
+type RotateAccountKeysParams struct {
+	// XSignature Ed25519 signature of the request body, base64 encoded.
+	// Generated using the account's current private signing key.
+	XSignature XSignature `json:"X-Signature"`
+}
+
+// RotateDiaryKeyParams defines parameters for RotateDiaryKey.
+type RotateDiaryKeyParams struct {
+	// XSignature Ed25519 signature of the request body, base64 encoded.
+	// Generated using the account's private signing key.
+	XSignature XSignature `json:"X-Signature"`
+}
+
+// ShareDiaryParams defines parameters for ShareDiary.
+type ShareDiaryParams struct {
+	// XSignature Ed25519 signature of the request body, base64 encoded.
+	// Generated using the account's private signing key.
+	XSignature XSignature `json:"X-Signature"`
+}
+
+// PutDiaryKeyEnvelopesParams defines parameters for PutDiaryKeyEnvelopes.
+type PutDiaryKeyEnvelopesParams struct {
+	// XSignature Ed25519 signature of the request body, base64 encoded.
+	// Generated using the account's private signing key.
+	XSignature XSignature `json:"X-Signature"`
+}
+
 // PutDiaryParams defines parameters for PutDiary.
 type PutDiaryParams struct {
 	// XSignature Ed25519 signature of the request body, base64 encoded.
@@ -499,10 +847,34 @@ type PutDiaryParams struct {
 	XSignature XSignature `json:"X-Signature"`
 }
 
+// CreateAttachmentParams defines parameters for CreateAttachment.
+type CreateAttachmentParams struct {
+	// XSignature Ed25519 signature of the request body, base64 encoded.
+	// Generated using the account's private signing key.
+	XSignature XSignature `json:"X-Signature"`
+}
+
+// UploadAttachmentChunkParams defines parameters for UploadAttachmentChunk.
+type UploadAttachmentChunkParams struct {
+	// XSignature Ed25519 signature of the raw chunk body, base64 encoded.
+	// Generated using the account's private signing key.
+	XSignature XSignature `json:"X-Signature"`
+}
+
+// CompleteAttachmentParams defines parameters for CompleteAttachment.
+type CompleteAttachmentParams struct {
+	// XSignature Ed25519 signature of the request body, base64 encoded.
+	// Generated using the account's private signing key.
+	XSignature XSignature `json:"X-Signature"`
+}
+
 // GetEntriesParams defines parameters for GetEntries.
 type GetEntriesParams struct {
 	// NextPageToken Token for pagination to retrieve the next page of results
 	NextPageToken *string `form:"next_page_token,omitempty" json:"next_page_token,omitempty"`
+
+	// SinceVersion Only return items with a version greater than this one
+	SinceVersion *uint64 `form:"since_version,omitempty" json:"since_version,omitempty"`
 }
 
 // PutEntryParams defines parameters for PutEntry.
@@ -516,6 +888,9 @@ type PutEntryParams struct {
 type GetTemplatesParams struct {
 	// NextPageToken Token for pagination to retrieve the next page of results
 	NextPageToken *string `form:"next_page_token,omitempty" json:"next_page_token,omitempty"`
+
+	// SinceVersion Only return items with a version greater than this one
+	SinceVersion *uint64 `form:"since_version,omitempty" json:"since_version,omitempty"`
 }
 
 // PutTemplateParams defines parameters for PutTemplate.
@@ -529,6 +904,9 @@ type PutTemplateParams struct {
 type GetTopicsParams struct {
 	// NextPageToken Token for pagination to retrieve the next page of results
 	NextPageToken *string `form:"next_page_token,omitempty" json:"next_page_token,omitempty"`
+
+	// SinceVersion Only return items with a version greater than this one
+	SinceVersion *uint64 `form:"since_version,omitempty" json:"since_version,omitempty"`
 }
 
 // DeleteTopicParams defines parameters for DeleteTopic.
@@ -545,21 +923,57 @@ type PutTopicParams struct {
 	XSignature XSignature `json:"X-Signature"`
 }
 
+// ChangePasswordJSONRequestBody defines body for ChangePassword for application/json ContentType.
+type ChangePasswordJSONRequestBody = ChangePasswordRequest
+
+// DeviceLoginVerifyJSONRequestBody defines body for DeviceLoginVerify for application/json ContentType.
+type DeviceLoginVerifyJSONRequestBody = DeviceLoginVerifyRequest
+
 // LoginJSONRequestBody defines body for Login for application/json ContentType.
 type LoginJSONRequestBody = LoginRequest
 
 // LoginVerifyJSONRequestBody defines body for LoginVerify for application/json ContentType.
 type LoginVerifyJSONRequestBody = LoginVerifyRequest
 
+// RecoveryJSONRequestBody defines body for Recovery for application/json ContentType.
+type RecoveryJSONRequestBody = RecoveryRequest
+
+// RecoveryVerifyJSONRequestBody defines body for RecoveryVerify for application/json ContentType.
+type RecoveryVerifyJSONRequestBody = RecoveryVerifyRequest
+
 // RegisterJSONRequestBody defines body for Register for application/json ContentType.
 type RegisterJSONRequestBody = RegisterRequest
 
+// AuthorizeDeviceJSONRequestBody defines body for AuthorizeDevice for application/json ContentType.
+type AuthorizeDeviceJSONRequestBody = AuthorizeDeviceRequest
+
+// PutDeviceKeysJSONRequestBody defines body for PutDeviceKeys for application/json ContentType.
+type PutDeviceKeysJSONRequestBody = PutDeviceKeysRequest
+
 // CreateDiaryJSONRequestBody defines body for CreateDiary for application/json ContentType.
 type CreateDiaryJSONRequestBody = CreateDiaryRequest
 
 // PutDiaryJSONRequestBody defines body for PutDiary for application/json ContentType.
 type PutDiaryJSONRequestBody = PutDiaryRequest
 
+// RotateAccountKeysJSONRequestBody defines body for RotateAccountKeys for application/json ContentType.
+type RotateAccountKeysJSONRequestBody = RotateAccountKeysRequest
+
+// RotateDiaryKeyJSONRequestBody defines body for RotateDiaryKey for application/json ContentType.
+type RotateDiaryKeyJSONRequestBody = RotateDiaryKeyRequest
+
+// ShareDiaryJSONRequestBody defines body for ShareDiary for application/json ContentType.
+type ShareDiaryJSONRequestBody = ShareDiaryRequest
+
+// CreateAttachmentJSONRequestBody defines body for CreateAttachment for application/json ContentType.
+type CreateAttachmentJSONRequestBody = CreateAttachmentRequest
+
+// CompleteAttachmentJSONRequestBody defines body for CompleteAttachment for application/json ContentType.
+type CompleteAttachmentJSONRequestBody = CompleteAttachmentRequest
+
+// PutDiaryKeyEnvelopesJSONRequestBody defines body for PutDiaryKeyEnvelopes for application/json ContentType.
+type PutDiaryKeyEnvelopesJSONRequestBody = PutDiaryKeyEnvelopesRequest
+
 // PutEntryJSONRequestBody defines body for PutEntry for application/json ContentType.
 type PutEntryJSONRequestBody = PutEntryRequest
 
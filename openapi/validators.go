@@ -121,6 +121,46 @@ func (r *LoginVerifyRequest) Validate() error {
 	return nil
 }
 
+func (r *ChangePasswordRequest) Validate() error {
+	if r.OldPassword == "" {
+		return errors.New("old_password is required")
+	}
+
+	if strings.TrimSpace(r.NewPassword) == "" {
+		return errors.New("new_password must not be empty")
+	}
+
+	if r.OldPassword == r.NewPassword {
+		return errors.New("new_password must differ from old_password")
+	}
+
+	return nil
+}
+
+func (r *RecoveryRequest) Validate() error {
+	if r.Login == "" {
+		return errors.New("login is required")
+	}
+
+	return nil
+}
+
+func (r *RecoveryVerifyRequest) Validate() error {
+	if r.ChallengeId == "" {
+		return errors.New("challenge_id is required")
+	}
+
+	if len(r.SignedNonce) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid signed_nonce length: expected %d, got %d", ed25519.SignatureSize, len(r.SignedNonce))
+	}
+
+	if strings.TrimSpace(r.NewPassword) == "" {
+		return errors.New("new_password must not be empty")
+	}
+
+	return nil
+}
+
 func (r *CreateDiaryRequest) Validate() error {
 	if len(r.EncryptedDiaryKey) == 0 {
 		return errors.New("encrypted_diary_key is required")
@@ -240,3 +280,194 @@ func (r *PutTemplateRequest) Validate() error {
 
 	return nil
 }
+
+func isValidMemberRole(role DiaryMemberRole) bool {
+	return role == Editor || role == Viewer
+}
+
+func (r *ShareDiaryRequest) Validate() error {
+	if !isValidEncryptionPublicKey(r.EncryptionPublicKey) {
+		return errors.New("invalid Curve25519 encryption public key")
+	}
+
+	if !isValidMemberRole(r.Role) {
+		return fmt.Errorf("invalid role %q: must be %q or %q", r.Role, Editor, Viewer)
+	}
+
+	if len(r.EncryptedDiaryKeys) == 0 {
+		return errors.New("encrypted_diary_keys is required")
+	}
+
+	for _, envelope := range r.EncryptedDiaryKeys {
+		if envelope.DiaryKeyId == "" {
+			return errors.New("diary key id is required")
+		}
+
+		if len(envelope.Value) == 0 {
+			return errors.New("encrypted diary key value is required")
+		}
+	}
+
+	return nil
+}
+
+func (r *RotateDiaryKeyRequest) Validate() error {
+	if len(r.EncryptedDiaryKeys) == 0 {
+		return errors.New("encrypted_diary_keys is required")
+	}
+
+	for _, recipient := range r.EncryptedDiaryKeys {
+		if !isValidEncryptionPublicKey(recipient.EncryptionPublicKey) {
+			return errors.New("invalid Curve25519 encryption public key")
+		}
+
+		if len(recipient.Value) == 0 {
+			return errors.New("encrypted diary key value is required")
+		}
+	}
+
+	return nil
+}
+
+func (r *PutDiaryKeyEnvelopesRequest) Validate() error {
+	if !isValidEncryptionPublicKey(r.EncryptionPublicKey) {
+		return errors.New("invalid Curve25519 encryption public key")
+	}
+
+	if len(r.EncryptedDiaryKeys) == 0 {
+		return errors.New("encrypted_diary_keys is required")
+	}
+
+	for _, envelope := range r.EncryptedDiaryKeys {
+		if envelope.DiaryKeyId == "" {
+			return errors.New("diary key id is required")
+		}
+
+		if len(envelope.Value) == 0 {
+			return errors.New("encrypted diary key value is required")
+		}
+	}
+
+	return nil
+}
+
+func (r *RotateAccountKeysRequest) Validate() error {
+	if !isValidEncryptionPublicKey(r.EncryptionPublicKey) {
+		return errors.New("invalid Curve25519 encryption public key")
+	}
+
+	if !isValidSignaturePublicKey(r.SignaturePublicKey) {
+		return errors.New("invalid Ed25519 signature public key")
+	}
+
+	if len(r.SignatureProof) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid signature_proof length: expected %d, got %d", ed25519.SignatureSize, len(r.SignatureProof))
+	}
+
+	return nil
+}
+
+func (r *AuthorizeDeviceRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("name must not be empty")
+	}
+
+	if !isValidEncryptionPublicKey(r.EncryptionPublicKey) {
+		return errors.New("invalid Curve25519 encryption public key")
+	}
+
+	if !isValidSignaturePublicKey(r.SignaturePublicKey) {
+		return errors.New("invalid Ed25519 signature public key")
+	}
+
+	if len(r.DeviceKeySignature) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid device_key_signature length: expected %d, got %d", ed25519.SignatureSize, len(r.DeviceKeySignature))
+	}
+
+	return nil
+}
+
+func (r *PutDeviceKeysRequest) Validate() error {
+	if len(r.EncryptedDiaryKeys) == 0 {
+		return errors.New("encrypted_diary_keys is required")
+	}
+
+	for _, envelope := range r.EncryptedDiaryKeys {
+		if envelope.DiaryId == "" {
+			return errors.New("diary id is required")
+		}
+
+		if envelope.DiaryKeyId == "" {
+			return errors.New("diary key id is required")
+		}
+
+		if len(envelope.Value) == 0 {
+			return errors.New("encrypted diary key value is required")
+		}
+	}
+
+	return nil
+}
+
+func (r *DeviceLoginVerifyRequest) Validate() error {
+	if r.ChallengeId == "" {
+		return errors.New("challenge_id is required")
+	}
+
+	if r.DeviceId == "" {
+		return errors.New("device_id is required")
+	}
+
+	if len(r.SignedNonce) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid signed_nonce length: expected %d, got %d", ed25519.SignatureSize, len(r.SignedNonce))
+	}
+
+	return nil
+}
+
+// Attachment chunk limits
+const (
+	// MinAttachmentChunkSize is the smallest allowed plaintext chunk size (4 KiB)
+	MinAttachmentChunkSize = 4 * 1024
+
+	// MaxAttachmentChunkSize is the largest allowed plaintext chunk size (8 MiB)
+	MaxAttachmentChunkSize = 8 * 1024 * 1024
+
+	// AttachmentNoncePrefixSize is the length of the random per-attachment nonce prefix
+	AttachmentNoncePrefixSize = 7
+
+	// AttachmentContentIDSize is the length of an attachment content ID
+	AttachmentContentIDSize = 32
+)
+
+func (r *CreateAttachmentRequest) Validate() error {
+	if err := r.Encryption.Validate(); err != nil {
+		return err
+	}
+
+	if r.ChunkSize < MinAttachmentChunkSize || r.ChunkSize > MaxAttachmentChunkSize {
+		return fmt.Errorf("chunk_size %d is out of range [%d, %d]", r.ChunkSize, MinAttachmentChunkSize, MaxAttachmentChunkSize)
+	}
+
+	if len(r.NoncePrefix) != AttachmentNoncePrefixSize {
+		return fmt.Errorf("invalid nonce_prefix length: expected %d, got %d", AttachmentNoncePrefixSize, len(r.NoncePrefix))
+	}
+
+	if len(r.ContentId) != 0 && len(r.ContentId) != AttachmentContentIDSize {
+		return fmt.Errorf("invalid content_id length: expected %d, got %d", AttachmentContentIDSize, len(r.ContentId))
+	}
+
+	return nil
+}
+
+func (r *CompleteAttachmentRequest) Validate() error {
+	if r.ChunkCount <= 0 {
+		return errors.New("chunk_count must be positive")
+	}
+
+	if len(r.ContentId) != 0 && len(r.ContentId) != AttachmentContentIDSize {
+		return fmt.Errorf("invalid content_id length: expected %d, got %d", AttachmentContentIDSize, len(r.ContentId))
+	}
+
+	return nil
+}
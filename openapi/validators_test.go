@@ -583,3 +583,170 @@ func TestIsValidSignaturePublicKey(t *testing.T) {
 		})
 	}
 }
+
+func TestShareDiaryRequest_Validate(t *testing.T) {
+	// Generate valid key
+	var privateKey [32]byte
+	copy(privateKey[:], pbkdf2.Key([]byte("test-seed-phrase"), []byte("my-app-context"), 100_000, 32, sha256.New))
+	var publicKey [32]byte
+	curve25519.ScalarBaseMult(&publicKey, &privateKey)
+
+	validEnvelopes := []DiaryKeyEnvelope{
+		{DiaryKeyId: "key-1", Value: []byte("encrypted-diary-key")},
+	}
+
+	testCases := []struct {
+		name        string
+		publicKey   []byte
+		role        DiaryMemberRole
+		envelopes   []DiaryKeyEnvelope
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "valid editor",
+			publicKey:   publicKey[:],
+			role:        Editor,
+			envelopes:   validEnvelopes,
+			expectError: false,
+		},
+		{
+			name:        "valid viewer",
+			publicKey:   publicKey[:],
+			role:        Viewer,
+			envelopes:   validEnvelopes,
+			expectError: false,
+		},
+		{
+			name:        "owner role cannot be granted",
+			publicKey:   publicKey[:],
+			role:        Owner,
+			envelopes:   validEnvelopes,
+			expectError: true,
+			errorMsg:    "invalid role",
+		},
+		{
+			name:        "invalid public key",
+			publicKey:   make([]byte, 32),
+			role:        Viewer,
+			envelopes:   validEnvelopes,
+			expectError: true,
+			errorMsg:    "invalid Curve25519 encryption public key",
+		},
+		{
+			name:        "no envelopes",
+			publicKey:   publicKey[:],
+			role:        Viewer,
+			envelopes:   nil,
+			expectError: true,
+			errorMsg:    "encrypted_diary_keys is required",
+		},
+		{
+			name:        "envelope without key id",
+			publicKey:   publicKey[:],
+			role:        Viewer,
+			envelopes:   []DiaryKeyEnvelope{{Value: []byte("encrypted-diary-key")}},
+			expectError: true,
+			errorMsg:    "diary key id is required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &ShareDiaryRequest{
+				EncryptionPublicKey: tc.publicKey,
+				Role:                tc.role,
+				EncryptedDiaryKeys:  tc.envelopes,
+			}
+
+			err := req.Validate()
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateAttachmentRequest_Validate(t *testing.T) {
+	validEncryption := DiaryEncryption{
+		DiaryKeyId:        "key-1",
+		EncryptedKeyNonce: []byte("nonce"),
+		EncryptedKeyData:  []byte("encrypted-key"),
+	}
+
+	testCases := []struct {
+		name        string
+		chunkSize   int
+		noncePrefix []byte
+		contentID   []byte
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "valid without content id",
+			chunkSize:   1024 * 1024,
+			noncePrefix: make([]byte, AttachmentNoncePrefixSize),
+			expectError: false,
+		},
+		{
+			name:        "valid with content id",
+			chunkSize:   MinAttachmentChunkSize,
+			noncePrefix: make([]byte, AttachmentNoncePrefixSize),
+			contentID:   make([]byte, AttachmentContentIDSize),
+			expectError: false,
+		},
+		{
+			name:        "chunk size too small",
+			chunkSize:   MinAttachmentChunkSize - 1,
+			noncePrefix: make([]byte, AttachmentNoncePrefixSize),
+			expectError: true,
+			errorMsg:    "chunk_size",
+		},
+		{
+			name:        "chunk size too large",
+			chunkSize:   MaxAttachmentChunkSize + 1,
+			noncePrefix: make([]byte, AttachmentNoncePrefixSize),
+			expectError: true,
+			errorMsg:    "chunk_size",
+		},
+		{
+			name:        "invalid nonce prefix",
+			chunkSize:   1024 * 1024,
+			noncePrefix: make([]byte, 12),
+			expectError: true,
+			errorMsg:    "invalid nonce_prefix length",
+		},
+		{
+			name:        "invalid content id",
+			chunkSize:   1024 * 1024,
+			noncePrefix: make([]byte, AttachmentNoncePrefixSize),
+			contentID:   []byte("short"),
+			expectError: true,
+			errorMsg:    "invalid content_id length",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &CreateAttachmentRequest{
+				ChunkSize:   tc.chunkSize,
+				NoncePrefix: tc.noncePrefix,
+				ContentId:   tc.contentID,
+				Encryption:  validEncryption,
+			}
+
+			err := req.Validate()
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -3,8 +3,9 @@ package client
 import "time"
 
 type options struct {
-	baseURL string
-	timeout time.Duration
+	baseURL   string
+	timeout   time.Duration
+	revisions *RevisionStore
 }
 
 func defaultOptions() *options {
@@ -29,3 +30,11 @@ func WithTimeout(timeout time.Duration) clientOption {
 		}
 	}
 }
+
+// WithRevisionStore keeps the prior version of an entry in store whenever PutEntry
+// overwrites it
+func WithRevisionStore(store *RevisionStore) clientOption {
+	return func(o *options) {
+		o.revisions = store
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// PurgeTrash permanently deletes the entries, topics and templates of a diary that
+// were deleted more than olderThan ago; zero purges the whole trash. Purged entities
+// cannot be restored. It returns the number of purged entities, also on failure.
+func (c *Client) PurgeTrash(ctx context.Context, diaryID string, olderThan time.Duration) (int, error) {
+	if c.credentials == nil {
+		return 0, ErrUnauthorized
+	}
+
+	trash, err := c.ListTrash(ctx, diaryID)
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	expired := func(deletedAt time.Time) bool {
+		return !deletedAt.After(cutoff)
+	}
+
+	// Entries go first, as they may reference topics
+	var paths []string
+	for _, entry := range trash.Entries {
+		if expired(entry.DeletedAt.MustGet()) {
+			paths = append(paths, "entries/"+entry.ID)
+		}
+	}
+
+	for _, template := range trash.Templates {
+		if expired(template.DeletedAt.MustGet()) {
+			paths = append(paths, "templates/"+template.ID)
+		}
+	}
+
+	for _, topic := range trash.Topics {
+		if expired(topic.DeletedAt.MustGet()) {
+			paths = append(paths, "topics/"+topic.ID)
+		}
+	}
+
+	purged := 0
+	for _, path := range paths {
+		if err := c.purge(ctx, diaryID, path); err != nil {
+			return purged, errors.Wrapf(err, "failed to purge %s", path)
+		}
+
+		purged++
+	}
+
+	return purged, nil
+}
+
+// purge permanently deletes the diary resource at path
+func (c *Client) purge(ctx context.Context, diaryID, path string) error {
+	u, err := url.Parse(fmt.Sprintf("%s/v1/diaries/%s/%s", c.baseURL, diaryID, path))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse URL")
+	}
+
+	q := url.Values{}
+	q.Set("permanent", "true")
+	u.RawQuery = q.Encode()
+
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	// Already purged, e.g. by another device
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -16,11 +16,19 @@ import (
 type PutDiaryParams struct {
 	Title       string
 	Description string
+
+	// unknown holds details fields of the stored diary that this client does not know.
+	// When nil, PutDiary keeps those of the stored diary.
+	unknown unknownFields
 }
 
 // GetDiaryDetails extracts diary details from parameters
 func (p PutDiaryParams) GetDiaryDetails() DiaryDetails {
-	return DiaryDetails(p)
+	return DiaryDetails{
+		Title:       p.Title,
+		Description: p.Description,
+		unknown:     p.unknown,
+	}
 }
 
 func (c *Client) PutDiary(ctx context.Context, diaryID string, params PutDiaryParams) (*Diary, error) {
@@ -37,6 +45,15 @@ func (c *Client) PutDiary(ctx context.Context, diaryID string, params PutDiaryPa
 		return nil, errors.New("no encryption keys found in diary")
 	}
 
+	if params.unknown == nil {
+		stored, err := c.decryptDiary(diaryData)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt current diary")
+		}
+
+		params.unknown = stored.unknown
+	}
+
 	diaryKeyID := diaryData.EncryptionKeys[0].Id
 
 	entityKey, err := generateSymmetricKey()
@@ -164,6 +181,7 @@ func (c *Client) PutDiary(ctx context.Context, diaryID string, params PutDiaryPa
 		CreatedAt:   apiResponse.Diary.CreatedAt,
 		UpdatedAt:   apiResponse.Diary.UpdatedAt,
 		Version:     apiResponse.Diary.Version,
+		unknown:     decryptedDiaryDetails.unknown,
 	}
 
 	return &diary, nil
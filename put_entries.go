@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// EntryWrite is an entry to store with PutEntries
+type EntryWrite struct {
+	ID     string
+	Params PutEntryParams
+}
+
+// PutEntries creates or updates many entries of a diary, fetching the diary keys
+// once. Entries are stored in order; on failure the entries stored so far are
+// returned along with the error.
+func (c *Client) PutEntries(ctx context.Context, diaryID string, writes []EntryWrite) ([]*Entry, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	if len(writes) == 0 {
+		return nil, nil
+	}
+
+	for _, write := range writes {
+		if err := write.Params.Metadata.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid metadata of entry %s", write.ID)
+		}
+	}
+
+	// Get encryption keys
+	key, err := c.getActiveDiaryKey(ctx, diaryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get active diary key")
+	}
+
+	entries := make([]*Entry, 0, len(writes))
+	for _, write := range writes {
+		if err := c.saveEntryRevision(ctx, diaryID, write.ID); err != nil {
+			return entries, err
+		}
+
+		if write.Params.unknown == nil {
+			if write.Params.unknown, err = c.storedEntryUnknown(ctx, diaryID, write.ID); err != nil {
+				return entries, err
+			}
+		}
+
+		request, diaryKey, err := c.buildPutEntryRequest(key, write.Params)
+		if err != nil {
+			return entries, errors.Wrapf(err, "failed to build entry %s", write.ID)
+		}
+
+		apiEntry, err := c.sendPutEntry(ctx, diaryID, write.ID, request)
+		if err != nil {
+			return entries, errors.Wrapf(err, "failed to put entry %s", write.ID)
+		}
+
+		entry, err := c.decryptEntry(apiEntry, diaryKey)
+		if err != nil {
+			return entries, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
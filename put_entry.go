@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/samber/mo"
@@ -20,16 +21,41 @@ type PutEntryParams struct {
 	Archived      bool
 	Bookmarked    bool
 	PreviewHidden bool
+	Attachments   []AttachmentRef
+	Tags          []string
+	Metadata      EntryMetadata
+
+	// AuthoredAt sets when the entry was written, for entries created later than
+	// they were written, e.g. on import
+	AuthoredAt mo.Option[time.Time]
+
+	// unknown holds details fields of the stored entry that this client does not know.
+	// When nil, PutEntry keeps those of the stored entry.
+	unknown unknownFields
 }
 
 // GetEntryDetails extracts entry details from parameters
 func (p PutEntryParams) GetEntryDetails() EntryDetails {
-	return EntryDetails{
+	details := EntryDetails{
 		Content:       p.Content,
 		Archived:      p.Archived,
 		Bookmarked:    p.Bookmarked,
 		PreviewHidden: p.PreviewHidden,
+		Attachments:   p.Attachments,
+		Tags:          normalizeTags(p.Tags),
+		unknown:       p.unknown,
+	}
+
+	if !p.Metadata.IsZero() {
+		details.Metadata = &p.Metadata
+	}
+
+	if authoredAt, ok := p.AuthoredAt.Get(); ok {
+		authoredAt = authoredAt.UTC()
+		details.AuthoredAt = &authoredAt
 	}
+
+	return details
 }
 
 // GetEntryPreview extracts preview content (same as details for now)
@@ -43,42 +69,105 @@ func (c *Client) PutEntry(ctx context.Context, diaryID, entryID string, params P
 		return nil, ErrUnauthorized
 	}
 
+	if err := params.Metadata.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid entry metadata")
+	}
+
+	if err := c.saveEntryRevision(ctx, diaryID, entryID); err != nil {
+		return nil, err
+	}
+
+	if params.unknown == nil {
+		unknown, err := c.storedEntryUnknown(ctx, diaryID, entryID)
+		if err != nil {
+			return nil, err
+		}
+
+		params.unknown = unknown
+	}
+
 	// Get encryption keys
 	key, err := c.getActiveDiaryKey(ctx, diaryID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get active diary key")
 	}
 
+	request, diaryKey, err := c.buildPutEntryRequest(key, params)
+	if err != nil {
+		return nil, err
+	}
+
+	apiEntry, err := c.sendPutEntry(ctx, diaryID, entryID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypt and return entry
+	return c.decryptEntry(apiEntry, diaryKey)
+}
+
+// storedEntryUnknown returns the details fields of a stored entry that this client
+// does not know, nil when the entry does not exist yet
+func (c *Client) storedEntryUnknown(ctx context.Context, diaryID, entryID string) (unknownFields, error) {
+	apiEntry, err := c.getEntry(ctx, diaryID, entryID)
+	if errors.Is(err, ErrEntryNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get stored entry")
+	}
+
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	diaryKey, err := keyring.get(apiEntry.Encryption.DiaryKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := c.decryptEntry(apiEntry, diaryKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt stored entry")
+	}
+
+	return stored.unknown, nil
+}
+
+// buildPutEntryRequest encrypts entry parameters under a fresh entity key sealed with the diary key.
+// It returns the request along with the decrypted diary key.
+func (c *Client) buildPutEntryRequest(key *openapi.DiaryEncryptionKey, params PutEntryParams) (*openapi.PutEntryRequest, []byte, error) {
 	diaryKeyID := key.Id
 
 	// Generate entity key for entry encryption
 	entityKey, err := generateSymmetricKey()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate entity key")
+		return nil, nil, errors.Wrap(err, "failed to generate entity key")
 	}
 
 	// Encrypt entry details
 	entryDetails := params.GetEntryDetails()
 	entryDetailsJSON, err := json.Marshal(entryDetails)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to marshal entry details")
+		return nil, nil, errors.Wrap(err, "failed to marshal entry details")
 	}
 
 	detailsNonce, encryptedDetails, err := encryptWithSymmetricKey(entryDetailsJSON, entityKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to encrypt entry details")
+		return nil, nil, errors.Wrap(err, "failed to encrypt entry details")
 	}
 
 	// Encrypt entry preview (same as details for now)
 	entryPreview := params.GetEntryPreview()
 	entryPreviewJSON, err := json.Marshal(entryPreview)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to marshal entry preview")
+		return nil, nil, errors.Wrap(err, "failed to marshal entry preview")
 	}
 
 	previewNonce, encryptedPreview, err := encryptWithSymmetricKey(entryPreviewJSON, entityKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to encrypt entry preview")
+		return nil, nil, errors.Wrap(err, "failed to encrypt entry preview")
 	}
 
 	// Decrypt diary key
@@ -88,13 +177,13 @@ func (c *Client) PutEntry(ctx context.Context, diaryID, entryID string, params P
 		c.credentials.EncryptionPublicKey,
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decrypt diary key")
+		return nil, nil, errors.Wrap(err, "failed to decrypt diary key")
 	}
 
 	// Encrypt entity key with diary key
 	keyNonce, encryptedEntityKey, err := encryptWithSymmetricKey(entityKey, decryptedDiaryKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to encrypt entity key")
+		return nil, nil, errors.Wrap(err, "failed to encrypt entity key")
 	}
 
 	// Convert TopicID if present
@@ -124,9 +213,14 @@ func (c *Client) PutEntry(ctx context.Context, diaryID, entryID string, params P
 
 	// Validate request before sending
 	if err := request.Validate(); err != nil {
-		return nil, errors.Wrap(err, "request validation failed")
+		return nil, nil, errors.Wrap(err, "request validation failed")
 	}
 
+	return &request, decryptedDiaryKey, nil
+}
+
+// sendPutEntry signs and sends a put entry request
+func (c *Client) sendPutEntry(ctx context.Context, diaryID, entryID string, request *openapi.PutEntryRequest) (*openapi.Entry, error) {
 	url := fmt.Sprintf("%s/v1/diaries/%s/entries/%s", c.baseURL, diaryID, entryID)
 	req, err := c.newAuthenticatedRequest(ctx, http.MethodPut, url, request)
 	if err != nil {
@@ -155,6 +249,10 @@ func (c *Client) PutEntry(ctx context.Context, diaryID, entryID string, params P
 		return nil, ErrForbidden
 	}
 
+	if resp.StatusCode == http.StatusConflict {
+		return nil, ErrVersionConflict
+	}
+
 	if resp.StatusCode == http.StatusBadRequest {
 		var errorResp openapi.ErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil {
@@ -175,8 +273,7 @@ func (c *Client) PutEntry(ctx context.Context, diaryID, entryID string, params P
 		return nil, errors.Wrap(err, "failed to decode response")
 	}
 
-	// Decrypt and return entry
-	return c.decryptEntry(&apiResponse.Entry, decryptedDiaryKey)
+	return &apiResponse.Entry, nil
 }
 
 // decryptEntry decrypts an encrypted entry to plaintext
@@ -212,6 +309,11 @@ func (c *Client) decryptEntry(apiEntry *openapi.Entry, diaryKey []byte) (*Entry,
 		topicID = mo.Some(string(apiEntry.TopicId.MustGet()))
 	}
 
+	var metadata EntryMetadata
+	if entryDetails.Metadata != nil {
+		metadata = *entryDetails.Metadata
+	}
+
 	entry := Entry{
 		ID:            apiEntry.Id,
 		DiaryID:       string(apiEntry.DiaryId),
@@ -220,6 +322,11 @@ func (c *Client) decryptEntry(apiEntry *openapi.Entry, diaryKey []byte) (*Entry,
 		Archived:      entryDetails.Archived,
 		Bookmarked:    entryDetails.Bookmarked,
 		PreviewHidden: entryDetails.PreviewHidden,
+		Attachments:   entryDetails.Attachments,
+		Tags:          entryDetails.Tags,
+		Metadata:      metadata,
+		AuthoredAt:    entryDetails.authoredAt(),
+		unknown:       entryDetails.unknown,
 		CreatedAt:     apiEntry.CreatedAt,
 		UpdatedAt:     apiEntry.UpdatedAt,
 		DeletedAt:     apiEntry.DeletedAt,
@@ -73,6 +73,39 @@ func (s *ClientSuite) TestEntry_PutEntry() {
 	assert.Greater(t, updatedEntry.Version, createdEntry.Version)
 }
 
+func (s *ClientSuite) TestEntry_PutEntry_AuthoredAt() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-put-entry-authored-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Test Diary"})
+	require.NoError(t, err)
+
+	authored := time.Date(2019, 6, 1, 7, 15, 0, 0, time.UTC)
+
+	// Act
+	entry, err := s.client.PutEntry(ctx, diary.ID, uuid.NewString(), PutEntryParams{
+		Content:    "Written long ago",
+		AuthoredAt: mo.Some(authored),
+	})
+	require.NoError(t, err)
+
+	fetched, err := s.client.GetEntryByID(ctx, diary.ID, entry.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, mo.Some(authored), fetched.AuthoredAt)
+	assert.Equal(t, authored, fetched.EffectiveDate())
+	assert.True(t, fetched.CreatedAt.After(authored))
+}
+
 func (s *ClientSuite) TestEntry_PutEntry_WithTopic() {
 	t := s.T()
 	ctx := context.Background()
@@ -14,12 +14,29 @@ import (
 
 // PutTemplateParams contains parameters for creating/updating a template
 type PutTemplateParams struct {
-	Content string
+	Name        string
+	Description string
+	Icon        string
+	Color       string
+	Content     string
+	Prompts     []string
+
+	// unknown holds details fields of the stored template that this client does not know.
+	// When nil, PutTemplate keeps those of the stored template.
+	unknown unknownFields
 }
 
 // GetTemplateDetails extracts template details from parameters
 func (p PutTemplateParams) GetTemplateDetails() TemplateDetails {
-	return TemplateDetails(p)
+	return TemplateDetails{
+		Name:        p.Name,
+		Description: p.Description,
+		Icon:        p.Icon,
+		Color:       p.Color,
+		Content:     p.Content,
+		Prompts:     p.Prompts,
+		unknown:     p.unknown,
+	}
 }
 
 // PutTemplate creates or updates a template in a diary
@@ -28,30 +45,85 @@ func (c *Client) PutTemplate(ctx context.Context, diaryID, templateID string, pa
 		return nil, ErrUnauthorized
 	}
 
+	if params.unknown == nil {
+		unknown, err := c.storedTemplateUnknown(ctx, diaryID, templateID)
+		if err != nil {
+			return nil, err
+		}
+
+		params.unknown = unknown
+	}
+
 	// Get encryption keys
 	key, err := c.getActiveDiaryKey(ctx, diaryID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get active diary key")
 	}
 
+	request, diaryKey, err := c.buildPutTemplateRequest(key, params)
+	if err != nil {
+		return nil, err
+	}
+
+	apiTemplate, err := c.sendPutTemplate(ctx, diaryID, templateID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypt and return template
+	return c.decryptTemplate(apiTemplate, diaryKey)
+}
+
+// storedTemplateUnknown returns the details fields of a stored template that this client
+// does not know, nil when the template does not exist yet
+func (c *Client) storedTemplateUnknown(ctx context.Context, diaryID, templateID string) (unknownFields, error) {
+	apiTemplate, err := c.getTemplate(ctx, diaryID, templateID)
+	if errors.Is(err, ErrTemplateNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get stored template")
+	}
+
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	diaryKey, err := keyring.get(apiTemplate.Encryption.DiaryKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := c.decryptTemplate(apiTemplate, diaryKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt stored template")
+	}
+
+	return stored.unknown, nil
+}
+
+// buildPutTemplateRequest encrypts template parameters under a fresh entity key sealed with the diary key.
+// It returns the request along with the decrypted diary key.
+func (c *Client) buildPutTemplateRequest(key *openapi.DiaryEncryptionKey, params PutTemplateParams) (*openapi.PutTemplateRequest, []byte, error) {
 	diaryKeyID := key.Id
 
 	// Generate entity key for template encryption
 	entityKey, err := generateSymmetricKey()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate entity key")
+		return nil, nil, errors.Wrap(err, "failed to generate entity key")
 	}
 
 	// Encrypt template details
 	templateDetails := params.GetTemplateDetails()
 	templateDetailsJSON, err := json.Marshal(templateDetails)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to marshal template details")
+		return nil, nil, errors.Wrap(err, "failed to marshal template details")
 	}
 
 	detailsNonce, encryptedDetails, err := encryptWithSymmetricKey(templateDetailsJSON, entityKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to encrypt template details")
+		return nil, nil, errors.Wrap(err, "failed to encrypt template details")
 	}
 
 	// Decrypt diary key
@@ -61,13 +133,13 @@ func (c *Client) PutTemplate(ctx context.Context, diaryID, templateID string, pa
 		c.credentials.EncryptionPublicKey,
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decrypt diary key")
+		return nil, nil, errors.Wrap(err, "failed to decrypt diary key")
 	}
 
 	// Encrypt entity key with diary key
 	keyNonce, encryptedEntityKey, err := encryptWithSymmetricKey(entityKey, decryptedDiaryKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to encrypt entity key")
+		return nil, nil, errors.Wrap(err, "failed to encrypt entity key")
 	}
 
 	// Create request
@@ -86,9 +158,14 @@ func (c *Client) PutTemplate(ctx context.Context, diaryID, templateID string, pa
 
 	// Validate request before sending
 	if err := request.Validate(); err != nil {
-		return nil, errors.Wrap(err, "request validation failed")
+		return nil, nil, errors.Wrap(err, "request validation failed")
 	}
 
+	return &request, decryptedDiaryKey, nil
+}
+
+// sendPutTemplate signs and sends a put template request
+func (c *Client) sendPutTemplate(ctx context.Context, diaryID, templateID string, request *openapi.PutTemplateRequest) (*openapi.Template, error) {
 	url := fmt.Sprintf("%s/v1/diaries/%s/templates/%s", c.baseURL, diaryID, templateID)
 	req, err := c.newAuthenticatedRequest(ctx, http.MethodPut, url, request)
 	if err != nil {
@@ -117,6 +194,10 @@ func (c *Client) PutTemplate(ctx context.Context, diaryID, templateID string, pa
 		return nil, ErrForbidden
 	}
 
+	if resp.StatusCode == http.StatusConflict {
+		return nil, ErrVersionConflict
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
 	}
@@ -126,6 +207,5 @@ func (c *Client) PutTemplate(ctx context.Context, diaryID, templateID string, pa
 		return nil, errors.Wrap(err, "failed to decode response")
 	}
 
-	// Decrypt and return template
-	return c.decryptTemplate(&apiResponse.Template, decryptedDiaryKey)
+	return &apiResponse.Template, nil
 }
@@ -19,6 +19,11 @@ type PutTopicParams struct {
 	Description       string
 	Color             string
 	DefaultTemplateID mo.Option[string]
+	ParentID          mo.Option[string]
+
+	// unknown holds details fields of the stored topic that this client does not know.
+	// When nil, PutTopic keeps those of the stored topic.
+	unknown unknownFields
 }
 
 // GetTopicDetails extracts topic details from parameters
@@ -27,6 +32,8 @@ func (p PutTopicParams) GetTopicDetails() TopicDetails {
 		Title:       p.Title,
 		Description: p.Description,
 		Color:       p.Color,
+		ParentID:    p.ParentID.OrEmpty(),
+		unknown:     p.unknown,
 	}
 }
 
@@ -36,30 +43,91 @@ func (c *Client) PutTopic(ctx context.Context, diaryID, topicID string, params P
 		return nil, ErrUnauthorized
 	}
 
+	if parentID, ok := params.ParentID.Get(); ok {
+		if err := c.checkTopicParent(ctx, diaryID, topicID, parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.unknown == nil {
+		unknown, err := c.storedTopicUnknown(ctx, diaryID, topicID)
+		if err != nil {
+			return nil, err
+		}
+
+		params.unknown = unknown
+	}
+
 	// Get encryption keys
 	key, err := c.getActiveDiaryKey(ctx, diaryID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get active diary key")
 	}
 
+	request, diaryKey, err := c.buildPutTopicRequest(key, params)
+	if err != nil {
+		return nil, err
+	}
+
+	apiTopic, err := c.sendPutTopic(ctx, diaryID, topicID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypt and return topic
+	return c.decryptTopic(apiTopic, diaryKey)
+}
+
+// storedTopicUnknown returns the details fields of a stored topic that this client
+// does not know, nil when the topic does not exist yet
+func (c *Client) storedTopicUnknown(ctx context.Context, diaryID, topicID string) (unknownFields, error) {
+	apiTopic, err := c.getTopic(ctx, diaryID, topicID)
+	if errors.Is(err, ErrTopicNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get stored topic")
+	}
+
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	diaryKey, err := keyring.get(apiTopic.Encryption.DiaryKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := c.decryptTopic(apiTopic, diaryKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt stored topic")
+	}
+
+	return stored.unknown, nil
+}
+
+// buildPutTopicRequest encrypts topic parameters under a fresh entity key sealed with the diary key.
+// It returns the request along with the decrypted diary key.
+func (c *Client) buildPutTopicRequest(key *openapi.DiaryEncryptionKey, params PutTopicParams) (*openapi.PutTopicRequest, []byte, error) {
 	diaryKeyID := key.Id
 
 	// Generate entity key for topic encryption
 	entityKey, err := generateSymmetricKey()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate entity key")
+		return nil, nil, errors.Wrap(err, "failed to generate entity key")
 	}
 
 	// Encrypt topic details
 	topicDetails := params.GetTopicDetails()
 	topicDetailsJSON, err := json.Marshal(topicDetails)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to marshal topic details")
+		return nil, nil, errors.Wrap(err, "failed to marshal topic details")
 	}
 
 	detailsNonce, encryptedDetails, err := encryptWithSymmetricKey(topicDetailsJSON, entityKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to encrypt topic details")
+		return nil, nil, errors.Wrap(err, "failed to encrypt topic details")
 	}
 
 	// Decrypt diary key
@@ -69,13 +137,13 @@ func (c *Client) PutTopic(ctx context.Context, diaryID, topicID string, params P
 		c.credentials.EncryptionPublicKey,
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decrypt diary key")
+		return nil, nil, errors.Wrap(err, "failed to decrypt diary key")
 	}
 
 	// Encrypt entity key with diary key
 	keyNonce, encryptedEntityKey, err := encryptWithSymmetricKey(entityKey, decryptedDiaryKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to encrypt entity key")
+		return nil, nil, errors.Wrap(err, "failed to encrypt entity key")
 	}
 
 	var apiDefaultTemplateID mo.Option[openapi.TemplateID]
@@ -100,9 +168,14 @@ func (c *Client) PutTopic(ctx context.Context, diaryID, topicID string, params P
 
 	// Validate request before sending
 	if err := request.Validate(); err != nil {
-		return nil, errors.Wrap(err, "request validation failed")
+		return nil, nil, errors.Wrap(err, "request validation failed")
 	}
 
+	return &request, decryptedDiaryKey, nil
+}
+
+// sendPutTopic signs and sends a put topic request
+func (c *Client) sendPutTopic(ctx context.Context, diaryID, topicID string, request *openapi.PutTopicRequest) (*openapi.Topic, error) {
 	url := fmt.Sprintf("%s/v1/diaries/%s/topics/%s", c.baseURL, diaryID, topicID)
 	req, err := c.newAuthenticatedRequest(ctx, http.MethodPut, url, request)
 	if err != nil {
@@ -131,6 +204,10 @@ func (c *Client) PutTopic(ctx context.Context, diaryID, topicID string, params P
 		return nil, ErrForbidden
 	}
 
+	if resp.StatusCode == http.StatusConflict {
+		return nil, ErrVersionConflict
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
 	}
@@ -140,8 +217,7 @@ func (c *Client) PutTopic(ctx context.Context, diaryID, topicID string, params P
 		return nil, errors.Wrap(err, "failed to decode response")
 	}
 
-	// Decrypt and return topic
-	return c.decryptTopic(&apiResponse.Topic, decryptedDiaryKey)
+	return &apiResponse.Topic, nil
 }
 
 // decryptTopic decrypts an encrypted topic to plaintext
@@ -176,6 +252,11 @@ func (c *Client) decryptTopic(apiTopic *openapi.Topic, diaryKey []byte) (*Topic,
 		defaultTemplateID = mo.Some(string(apiTopic.DefaultTemplateId.MustGet()))
 	}
 
+	var parentID mo.Option[string]
+	if topicDetails.ParentID != "" {
+		parentID = mo.Some(topicDetails.ParentID)
+	}
+
 	topic := &Topic{
 		ID:                apiTopic.Id,
 		DiaryID:           string(apiTopic.DiaryId),
@@ -183,10 +264,12 @@ func (c *Client) decryptTopic(apiTopic *openapi.Topic, diaryKey []byte) (*Topic,
 		Description:       topicDetails.Description,
 		Color:             topicDetails.Color,
 		DefaultTemplateID: defaultTemplateID,
+		ParentID:          parentID,
 		CreatedAt:         apiTopic.CreatedAt,
 		UpdatedAt:         apiTopic.UpdatedAt,
 		DeletedAt:         apiTopic.DeletedAt,
 		Version:           apiTopic.Version,
+		unknown:           topicDetails.unknown,
 	}
 
 	return topic, nil
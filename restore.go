@@ -0,0 +1,243 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// RestoreOptions contains options for restoring a backup
+type RestoreOptions struct {
+	// TrustedSigningKey verifies the backup signature, the signing key of the
+	// current account when empty
+	TrustedSigningKey []byte
+}
+
+// RestoreResult describes the diaries re-created by Restore
+type RestoreResult struct {
+	Diaries []*Diary
+
+	// IDs maps diary, entry, topic and template IDs of the backup to the restored ones
+	IDs map[string]string
+
+	// SkippedAttachments counts attachment references dropped because their content
+	// could not be copied from the original diary
+	SkippedAttachments int
+}
+
+// Restore verifies a backup written by Backup and re-creates its diaries as new
+// diaries of the current account, on the same or a different server. Every
+// entity gets a new ID and deleted entities are not restored. Restored entries
+// keep their effective date as AuthoredAt. Attachments are copied from the
+// original diaries when they are still available.
+//
+// The archive is read diary by diary and every diary is verified before it is
+// restored. When reading or restoring fails, the diaries restored so far are
+// returned with the error.
+func (c *Client) Restore(ctx context.Context, r io.Reader, opts RestoreOptions) (*RestoreResult, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	trustedKey := opts.TrustedSigningKey
+	if len(trustedKey) == 0 {
+		trustedKey = c.credentials.SigningPublicKey
+	}
+
+	archive, err := openBackup(r, ed25519.PublicKey(trustedKey))
+	if err != nil {
+		return nil, err
+	}
+
+	result := RestoreResult{
+		IDs: map[string]string{},
+	}
+
+	for {
+		content, err := archive.nextDiary()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return &result, err
+		}
+
+		diary, err := c.restoreDiary(ctx, *content, &result)
+		if err != nil {
+			return &result, errors.Wrapf(err, "failed to restore diary %s", content.Diary.Id)
+		}
+
+		result.Diaries = append(result.Diaries, diary)
+	}
+
+	return &result, nil
+}
+
+func (c *Client) restoreDiary(ctx context.Context, content backupDiary, result *RestoreResult) (*Diary, error) {
+	keyring, err := c.newDiaryKeyring(content.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := c.decryptDiary(content.Diary)
+	if err != nil {
+		return nil, err
+	}
+
+	diary, err := c.CreateDiary(ctx, CreateDiaryParams{
+		Title:       source.Title,
+		Description: source.Description,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create diary")
+	}
+
+	if len(source.unknown) > 0 {
+		if diary, err = c.PutDiary(ctx, diary.ID, source.PutParams()); err != nil {
+			return nil, errors.Wrap(err, "failed to update diary")
+		}
+	}
+
+	result.IDs[source.ID] = diary.ID
+
+	templates, err := decryptBackup(keyring, content.Templates, templateKeyID, c.decryptTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates {
+		if template.DeletedAt.IsPresent() {
+			continue
+		}
+
+		id := uuid.NewString()
+		if _, err := c.PutTemplate(ctx, diary.ID, id, template.PutParams()); err != nil {
+			return nil, errors.Wrapf(err, "failed to restore template %s", template.ID)
+		}
+
+		result.IDs[template.ID] = id
+	}
+
+	topics, err := decryptBackup(keyring, content.Topics, topicKeyID, c.decryptTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := buildTopicTree(topics)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parents are restored before their children so the new parent IDs are known
+	if err := c.restoreTopics(ctx, diary.ID, tree, result); err != nil {
+		return nil, err
+	}
+
+	entries, err := decryptBackup(keyring, content.Entries, entryKeyID, c.decryptEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.DeletedAt.IsPresent() {
+			continue
+		}
+
+		params := entry.PutParams()
+		params.TopicID = remapID(params.TopicID, result.IDs)
+		params.AuthoredAt = mo.Some(entry.EffectiveDate())
+
+		attachments := params.Attachments[:0]
+		for _, ref := range params.Attachments {
+			copied, err := c.copyAttachment(ctx, entry.DiaryID, diary.ID, ref)
+			if err != nil {
+				result.SkippedAttachments++
+				continue
+			}
+
+			attachments = append(attachments, *copied)
+		}
+		params.Attachments = attachments
+
+		id := uuid.NewString()
+		if _, err := c.PutEntry(ctx, diary.ID, id, params); err != nil {
+			return nil, errors.Wrapf(err, "failed to restore entry %s", entry.ID)
+		}
+
+		result.IDs[entry.ID] = id
+	}
+
+	return diary, nil
+}
+
+// restoreTopics restores topic nodes depth first
+func (c *Client) restoreTopics(ctx context.Context, diaryID string, nodes []*TopicNode, result *RestoreResult) error {
+	for _, node := range nodes {
+		params := node.Topic.PutParams()
+		params.ParentID = remapID(params.ParentID, result.IDs)
+		params.DefaultTemplateID = remapID(params.DefaultTemplateID, result.IDs)
+
+		id := uuid.NewString()
+		if _, err := c.PutTopic(ctx, diaryID, id, params); err != nil {
+			return errors.Wrapf(err, "failed to restore topic %s", node.Topic.ID)
+		}
+
+		result.IDs[node.Topic.ID] = id
+
+		if err := c.restoreTopics(ctx, diaryID, node.Children, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// remapID replaces a backup ID with the restored one, dropping references to
+// entities that were not restored
+func remapID(id mo.Option[string], ids map[string]string) mo.Option[string] {
+	value, ok := id.Get()
+	if !ok {
+		return id
+	}
+
+	if restored, ok := ids[value]; ok {
+		return mo.Some(restored)
+	}
+
+	return mo.None[string]()
+}
+
+// decryptBackup decrypts backed up entities with the keys they reference
+func decryptBackup[A any, T any](
+	keyring *diaryKeyring,
+	items []*A,
+	keyID func(*A) string,
+	decrypt func(*A, []byte) (*T, error),
+) ([]*T, error) {
+	result := make([]*T, 0, len(items))
+	for _, item := range items {
+		diaryKey, err := keyring.get(keyID(item))
+		if err != nil {
+			return nil, err
+		}
+
+		decrypted, err := decrypt(item, diaryKey)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, decrypted)
+	}
+
+	return result, nil
+}
+
+func entryKeyID(e *openapi.Entry) string       { return e.Encryption.DiaryKeyId }
+func topicKeyID(t *openapi.Topic) string       { return t.Encryption.DiaryKeyId }
+func templateKeyID(t *openapi.Template) string { return t.Encryption.DiaryKeyId }
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// RestoreEntry undoes the deletion of an entry by storing it again with a fresh
+// version. Entries that are not deleted are returned unchanged.
+func (c *Client) RestoreEntry(ctx context.Context, diaryID, entryID string) (*Entry, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	entry, err := c.GetEntryByID(ctx, diaryID, entryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get entry")
+	}
+
+	if entry.DeletedAt.IsAbsent() {
+		return entry, nil
+	}
+
+	return c.PutEntry(ctx, diaryID, entryID, entry.PutParams())
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// RestoreTemplate undoes the deletion of a template by storing it again with a
+// fresh version. Templates that are not deleted are returned unchanged.
+func (c *Client) RestoreTemplate(ctx context.Context, diaryID, templateID string) (*Template, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	template, err := c.GetTemplateByID(ctx, diaryID, templateID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get template")
+	}
+
+	if template.DeletedAt.IsAbsent() {
+		return template, nil
+	}
+
+	return c.PutTemplate(ctx, diaryID, templateID, template.PutParams())
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/samber/mo"
+)
+
+// RestoreTopic undoes the deletion of a topic by storing it again with a fresh
+// version. A topic whose parent is still deleted is restored as a top-level topic.
+// Topics that are not deleted are returned unchanged.
+func (c *Client) RestoreTopic(ctx context.Context, diaryID, topicID string) (*Topic, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	topics, err := c.GetTopics(ctx, diaryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get topics")
+	}
+
+	var topic *Topic
+	for _, t := range topics {
+		if t.ID == topicID {
+			topic = t
+			break
+		}
+	}
+
+	if topic == nil {
+		return nil, ErrTopicNotFound
+	}
+
+	if topic.DeletedAt.IsAbsent() {
+		return topic, nil
+	}
+
+	params := topic.PutParams()
+	if parentID, ok := topic.ParentID.Get(); ok {
+		parentLive := false
+		for _, t := range topics {
+			if t.ID == parentID && t.DeletedAt.IsAbsent() {
+				parentLive = true
+				break
+			}
+		}
+
+		if !parentLive {
+			params.ParentID = mo.None[string]()
+		}
+	}
+
+	return c.PutTopic(ctx, diaryID, topicID, params)
+}
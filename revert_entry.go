@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// RevertEntry stores the content of an earlier revision as the newest version of
+// an entry. The version being replaced becomes a revision itself, so reverting can
+// be undone.
+func (c *Client) RevertEntry(ctx context.Context, diaryID, entryID string, version uint64) (*Entry, error) {
+	revisions, err := c.GetEntryRevisions(ctx, diaryID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, revision := range revisions {
+		if revision.Version == version {
+			return c.PutEntry(ctx, diaryID, entryID, revision.PutParams())
+		}
+	}
+
+	return nil, errors.Wrapf(ErrRevisionNotFound, "version %d", version)
+}
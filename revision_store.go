@@ -0,0 +1,151 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// MaxEntryRevisions is the number of revisions a RevisionStore keeps per entry;
+// older revisions are dropped first
+const MaxEntryRevisions = 100
+
+// RevisionStore keeps prior versions of entries as the server returned them, so
+// revisions stay encrypted under the diary keys at rest.
+// Revisions are persisted as one JSON file per entry when the store is backed by a directory.
+type RevisionStore struct {
+	mu        sync.Mutex
+	dir       string
+	revisions map[string][]*openapi.Entry
+}
+
+// NewRevisionStore opens a revision store persisted in dir, creating it on first write.
+// An empty dir creates a store kept in memory only.
+func NewRevisionStore(dir string) *RevisionStore {
+	return &RevisionStore{
+		dir:       dir,
+		revisions: map[string][]*openapi.Entry{},
+	}
+}
+
+// Save stores a revision of an entry. Saving a version that is already stored does nothing.
+func (s *RevisionStore) Save(diaryID string, entry *openapi.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revisions, err := s.load(diaryID, entry.Id)
+	if err != nil {
+		return err
+	}
+
+	for _, revision := range revisions {
+		if revision.Version == entry.Version {
+			return nil
+		}
+	}
+
+	revisions = append(revisions, entry)
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Version < revisions[j].Version
+	})
+
+	if len(revisions) > MaxEntryRevisions {
+		revisions = revisions[len(revisions)-MaxEntryRevisions:]
+	}
+
+	s.revisions[revisionKey(diaryID, entry.Id)] = revisions
+
+	return s.save(diaryID, entry.Id, revisions)
+}
+
+// List returns the stored revisions of an entry, oldest first
+func (s *RevisionStore) List(diaryID, entryID string) ([]*openapi.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revisions, err := s.load(diaryID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]*openapi.Entry(nil), revisions...), nil
+}
+
+// load returns the cached revisions of an entry, reading them from disk on first use
+func (s *RevisionStore) load(diaryID, entryID string) ([]*openapi.Entry, error) {
+	key := revisionKey(diaryID, entryID)
+	if revisions, ok := s.revisions[key]; ok || s.dir == "" {
+		return revisions, nil
+	}
+
+	path, err := s.path(diaryID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read revisions")
+	}
+
+	var revisions []*openapi.Entry
+	if err := json.Unmarshal(data, &revisions); err != nil {
+		return nil, errors.Wrap(err, "failed to parse revisions")
+	}
+
+	s.revisions[key] = revisions
+
+	return revisions, nil
+}
+
+// save persists the revisions of an entry atomically
+func (s *RevisionStore) save(diaryID, entryID string, revisions []*openapi.Entry) error {
+	if s.dir == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(revisions)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal revisions")
+	}
+
+	path, err := s.path(diaryID, entryID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return errors.Wrap(err, "failed to create revisions directory")
+	}
+
+	if err := writeFileAtomically(path, data); err != nil {
+		return errors.Wrap(err, "failed to save revisions")
+	}
+
+	return nil
+}
+
+// path returns the file holding the revisions of an entry. IDs come from the
+// server, so anything but a plain file name is rejected to stay inside dir.
+func (s *RevisionStore) path(diaryID, entryID string) (string, error) {
+	for _, id := range []string{diaryID, entryID} {
+		if !filepath.IsLocal(id) || filepath.Base(id) != id || id == "." {
+			return "", errors.Errorf("invalid revision store id %q", id)
+		}
+	}
+
+	return filepath.Join(s.dir, diaryID, entryID+".json"), nil
+}
+
+func revisionKey(diaryID, entryID string) string {
+	return diaryID + "/" + entryID
+}
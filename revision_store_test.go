@@ -0,0 +1,56 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+func TestRevisionStore_Persists(t *testing.T) {
+	dir := t.TempDir()
+
+	store := NewRevisionStore(dir)
+	require.NoError(t, store.Save("diary-1", &openapi.Entry{Id: "entry-1", Version: 2}))
+	require.NoError(t, store.Save("diary-1", &openapi.Entry{Id: "entry-1", Version: 1}))
+	require.NoError(t, store.Save("diary-1", &openapi.Entry{Id: "entry-1", Version: 2}))
+	require.NoError(t, store.Save("diary-1", &openapi.Entry{Id: "entry-2", Version: 5}))
+
+	reopened := NewRevisionStore(dir)
+	revisions, err := reopened.List("diary-1", "entry-1")
+	require.NoError(t, err)
+
+	require.Len(t, revisions, 2)
+	assert.Equal(t, uint64(1), revisions[0].Version)
+	assert.Equal(t, uint64(2), revisions[1].Version)
+}
+
+func TestRevisionStore_KeepsNewest(t *testing.T) {
+	store := NewRevisionStore("")
+	for version := uint64(1); version <= MaxEntryRevisions+5; version++ {
+		require.NoError(t, store.Save("diary-1", &openapi.Entry{Id: "entry-1", Version: version}))
+	}
+
+	revisions, err := store.List("diary-1", "entry-1")
+	require.NoError(t, err)
+
+	require.Len(t, revisions, MaxEntryRevisions)
+	assert.Equal(t, uint64(6), revisions[0].Version)
+}
+
+func TestRevisionStore_RejectsInvalidIDs(t *testing.T) {
+	store := NewRevisionStore(t.TempDir())
+
+	for _, id := range []string{"..", ".", "", "../diary-1", "diary/1", "/etc"} {
+		err := store.Save(id, &openapi.Entry{Id: "entry-1", Version: 1})
+		assert.Error(t, err, "diary id %q", id)
+
+		err = store.Save("diary-1", &openapi.Entry{Id: id, Version: 1})
+		assert.Error(t, err, "entry id %q", id)
+
+		_, err = store.List(id, "entry-1")
+		assert.Error(t, err, "diary id %q", id)
+	}
+}
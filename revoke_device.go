@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RevokeDevice removes a device and rotates the keys of every diary it had access
+// to, so a lost or stolen device cannot read content written afterwards.
+//
+// Only the owner of a shared diary can rotate its key. Diaries whose key was not
+// rotated are reported with a *DeviceKeyRotationError: the revoked device can
+// still read what is written there until their owners call RotateDiaryKey.
+func (c *Client) RevokeDevice(ctx context.Context, deviceID string) error {
+	if c.credentials == nil {
+		return ErrUnauthorized
+	}
+
+	url := fmt.Sprintf("%s/v1/devices/%s", c.baseURL, deviceID)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrDeviceNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	diariesData, err := c.getDiaries(ctx)
+	if err != nil {
+		return errors.Wrap(err, "device revoked but diaries could not be listed for key rotation")
+	}
+
+	rotationErr := DeviceKeyRotationError{Errors: map[string]error{}}
+	for _, diaryData := range diariesData {
+		if _, err := c.rotateDiaryKey(ctx, diaryData.Id); err != nil {
+			rotationErr.DiaryIDs = append(rotationErr.DiaryIDs, diaryData.Id)
+			rotationErr.Errors[diaryData.Id] = err
+		}
+	}
+
+	if len(rotationErr.DiaryIDs) > 0 {
+		return &rotationErr
+	}
+
+	return nil
+}
+
+// DeviceKeyRotationError reports the diaries whose key was not rotated after a
+// device was revoked. It matches ErrKeyRotationFailed with errors.Is.
+type DeviceKeyRotationError struct {
+	// DiaryIDs lists the diaries in the order they were tried
+	DiaryIDs []string
+
+	// Errors holds the rotation error of every diary, ErrForbidden for shared
+	// diaries the account does not own
+	Errors map[string]error
+}
+
+func (e *DeviceKeyRotationError) Error() string {
+	return fmt.Sprintf("device revoked but keys of diaries %s were not rotated", strings.Join(e.DiaryIDs, ", "))
+}
+
+func (e *DeviceKeyRotationError) Unwrap() error {
+	return ErrKeyRotationFailed
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestDevice_RevokeDevice() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: Enrolled device with access to a diary
+	var login = fmt.Sprintf("test-revoke-device-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	deviceCredentials, err := NewDeviceCredentials()
+	require.NoError(t, err)
+
+	device, err := s.client.AuthorizeDevice(ctx, AuthorizeDeviceParams{
+		Name:                "Stolen laptop",
+		SigningPublicKey:    deviceCredentials.SigningPublicKey,
+		EncryptionPublicKey: deviceCredentials.EncryptionPublicKey,
+	})
+	require.NoError(t, err)
+
+	keyBefore, err := s.client.getActiveDiaryKey(ctx, diary.ID)
+	require.NoError(t, err)
+
+	// Act
+	err = s.client.RevokeDevice(ctx, device.ID)
+	require.NoError(t, err)
+
+	// Assert: Device can no longer authenticate
+	deviceClient := NewClient(WithBaseURL("http://localhost:8081/api"))
+	err = deviceClient.AuthenticateDevice(ctx, login, "password-123", device.ID, deviceCredentials)
+	require.Error(t, err)
+
+	// Assert: Diary key was rotated and the primary device keeps working
+	keyAfter, err := s.client.getActiveDiaryKey(ctx, diary.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, keyBefore.Id, keyAfter.Id)
+
+	_, err = s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "After revocation"})
+	require.NoError(t, err)
+
+	devices, err := s.client.ListDevices(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+}
+
+func (s *ClientSuite) TestDevice_RevokeDevice_NotFound() {
+	t := s.T()
+	ctx := context.Background()
+
+	var login = fmt.Sprintf("test-revoke-device-not-found-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.RevokeDevice(ctx, uuid.NewString())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrDeviceNotFound)
+}
+
+func (s *ClientSuite) TestDevice_RevokeDevice_SharedDiary() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: A member with an enrolled device has access to a diary shared by its owner
+	var login = fmt.Sprintf("test-revoke-device-shared-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	memberClient, memberCredentials := s.registerMember(ctx, "test-revoke-device-shared-member")
+
+	shared, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Family Diary"})
+	require.NoError(t, err)
+
+	_, err = s.client.ShareDiary(ctx, shared.ID, memberCredentials.EncryptionPublicKey, DiaryRoleEditor)
+	require.NoError(t, err)
+
+	own, err := memberClient.CreateDiary(ctx, CreateDiaryParams{Title: "Own Diary"})
+	require.NoError(t, err)
+
+	deviceCredentials, err := NewDeviceCredentials()
+	require.NoError(t, err)
+
+	device, err := memberClient.AuthorizeDevice(ctx, AuthorizeDeviceParams{
+		Name:                "Stolen phone",
+		SigningPublicKey:    deviceCredentials.SigningPublicKey,
+		EncryptionPublicKey: deviceCredentials.EncryptionPublicKey,
+	})
+	require.NoError(t, err)
+
+	sharedKeyBefore, err := s.client.getActiveDiaryKey(ctx, shared.ID)
+	require.NoError(t, err)
+
+	ownKeyBefore, err := memberClient.getActiveDiaryKey(ctx, own.ID)
+	require.NoError(t, err)
+
+	// Act
+	err = memberClient.RevokeDevice(ctx, device.ID)
+
+	// Assert: The shared diary is reported, the own diary was rotated
+	require.ErrorIs(t, err, ErrKeyRotationFailed)
+
+	var rotationErr *DeviceKeyRotationError
+	require.True(t, errors.As(err, &rotationErr))
+	assert.Equal(t, []string{shared.ID}, rotationErr.DiaryIDs)
+	assert.ErrorIs(t, rotationErr.Errors[shared.ID], ErrForbidden)
+
+	sharedKeyAfter, err := s.client.getActiveDiaryKey(ctx, shared.ID)
+	require.NoError(t, err)
+	assert.Equal(t, sharedKeyBefore.Id, sharedKeyAfter.Id)
+
+	ownKeyAfter, err := memberClient.getActiveDiaryKey(ctx, own.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, ownKeyBefore.Id, ownKeyAfter.Id)
+
+	// Assert: The owner finishes the revocation
+	require.NoError(t, s.client.RotateDiaryKey(ctx, shared.ID))
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// RevokeMember removes an account's access to a diary and rotates the diary key,
+// so that entities written afterwards are unreadable to the revoked member.
+//
+// The member is removed before the key is rotated. When the rotation fails the
+// error wraps ErrKeyRotationFailed: the member is already gone, but still holds
+// the active key until RotateDiaryKey succeeds.
+func (c *Client) RevokeMember(ctx context.Context, diaryID, accountID string) error {
+	if c.credentials == nil {
+		return ErrUnauthorized
+	}
+
+	url := fmt.Sprintf("%s/v1/diaries/%s/members/%s", c.baseURL, diaryID, accountID)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		var errorResp openapi.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil {
+			if errorResp.ErrorCode == openapi.ResponseErrorCodeDiaryNotFound {
+				return ErrDiaryNotFound
+			}
+		}
+
+		return ErrMemberNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	if _, err := c.rotateDiaryKey(ctx, diaryID); err != nil {
+		return errors.Wrapf(ErrKeyRotationFailed, "member revoked: %v", err)
+	}
+
+	return nil
+}
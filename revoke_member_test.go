@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestDiary_RevokeMember() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: Share a diary with a member
+	var login = fmt.Sprintf("test-revoke-member-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	memberClient, memberCredentials := s.registerMember(ctx, "test-revoke-member-member")
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Family Diary"})
+	require.NoError(t, err)
+
+	entryBefore, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Before revoke"})
+	require.NoError(t, err)
+
+	member, err := s.client.ShareDiary(ctx, diary.ID, memberCredentials.EncryptionPublicKey, DiaryRoleEditor)
+	require.NoError(t, err)
+
+	keyBefore, err := s.client.getActiveDiaryKey(ctx, diary.ID)
+	require.NoError(t, err)
+
+	// Act
+	err = s.client.RevokeMember(ctx, diary.ID, member.AccountID)
+	require.NoError(t, err)
+
+	// Assert: Diary key was rotated
+	keyAfter, err := s.client.getActiveDiaryKey(ctx, diary.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, keyBefore.Id, keyAfter.Id)
+
+	// Assert: Owner still reads entries written under the previous key
+	gotEntry, err := s.client.GetEntryByID(ctx, diary.ID, entryBefore.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Before revoke", gotEntry.Content)
+
+	_, err = s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "After revoke"})
+	require.NoError(t, err)
+
+	entries, err := s.client.GetEntries(ctx, diary.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	// Assert: Revoked member lost access
+	_, err = memberClient.GetEntries(ctx, diary.ID)
+	require.Error(t, err)
+}
+
+func (s *ClientSuite) TestDiary_RevokeMember_NotFound() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-revoke-member-not-found-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Family Diary"})
+	require.NoError(t, err)
+
+	// Act
+	err = s.client.RevokeMember(ctx, diary.ID, uuid.NewString())
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrMemberNotFound)
+}
+
+func (s *ClientSuite) TestDiary_RotateDiaryKey() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-rotate-key-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Before rotation"})
+	require.NoError(t, err)
+
+	keyBefore, err := s.client.getActiveDiaryKey(ctx, diary.ID)
+	require.NoError(t, err)
+
+	// Act
+	err = s.client.RotateDiaryKey(ctx, diary.ID)
+
+	// Assert
+	require.NoError(t, err)
+
+	keyAfter, err := s.client.getActiveDiaryKey(ctx, diary.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, keyBefore.Id, keyAfter.Id)
+
+	gotEntry, err := s.client.GetEntryByID(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Before rotation", gotEntry.Content)
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// RotateAccountKeys moves the account to the key pair derived from newSeedPhrase.
+//
+// Every diary key is re-sealed to the new encryption public key before the new
+// public keys are registered with the server, so no diary becomes unreadable.
+// The operation is idempotent: if it fails part way, calling it again with the
+// same seed phrase resumes it. Diaries whose keys are already sealed to the new
+// key pair are skipped.
+func (c *Client) RotateAccountKeys(ctx context.Context, newSeedPhrase string) error {
+	if c.credentials == nil {
+		return ErrUnauthorized
+	}
+
+	newCredentials, err := NewCredentials(newSeedPhrase)
+	if err != nil {
+		return err
+	}
+
+	if newCredentials.AccountKeys().Equal(c.credentials.AccountKeys()) {
+		return nil
+	}
+
+	diariesData, err := c.getDiaries(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get diaries")
+	}
+
+	committed := len(diariesData) > 0
+	for _, diaryData := range diariesData {
+		rotated, err := c.resealDiaryKeys(ctx, diaryData.Id, newCredentials)
+		if err != nil {
+			return errors.Wrapf(err, "failed to re-encrypt keys of diary %s", diaryData.Id)
+		}
+
+		committed = committed && rotated
+	}
+
+	// The server only hands out keys sealed to the new key pair once the rotation was committed
+	if !committed {
+		if err := c.commitAccountKeys(ctx, newCredentials); err != nil {
+			return errors.Wrap(err, "failed to register new account keys")
+		}
+	}
+
+	c.credentials = newCredentials
+
+	return nil
+}
+
+// resealDiaryKeys stores every key of a diary sealed to the new encryption public key.
+// It reports true without uploading anything when the keys are already sealed to it.
+func (c *Client) resealDiaryKeys(ctx context.Context, diaryID string, newCredentials *Credentials) (bool, error) {
+	keys, err := c.getDiaryKeys(ctx, diaryID)
+	if err != nil {
+		return false, err
+	}
+
+	envelopes := make([]openapi.DiaryKeyEnvelope, 0, len(keys))
+	for _, key := range keys {
+		diaryKey, err := decryptWithPrivateKey(
+			key.Value,
+			c.credentials.EncryptionPrivateKey,
+			c.credentials.EncryptionPublicKey,
+		)
+		if err != nil {
+			// Keys from a previous, already committed attempt open with the new credentials
+			if _, newErr := decryptWithPrivateKey(
+				key.Value,
+				newCredentials.EncryptionPrivateKey,
+				newCredentials.EncryptionPublicKey,
+			); newErr == nil {
+				return true, nil
+			}
+
+			return false, errors.Wrap(err, "failed to decrypt diary key")
+		}
+
+		encryptedDiaryKey, err := encryptWithPublicKey(diaryKey, newCredentials.EncryptionPublicKey)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to encrypt diary key")
+		}
+
+		envelopes = append(envelopes, openapi.DiaryKeyEnvelope{
+			DiaryKeyId: key.Id,
+			Value:      encryptedDiaryKey,
+		})
+	}
+
+	request := openapi.PutDiaryKeyEnvelopesRequest{
+		EncryptionPublicKey: newCredentials.EncryptionPublicKey,
+		EncryptedDiaryKeys:  envelopes,
+	}
+
+	// Validate request before sending
+	if err := request.Validate(); err != nil {
+		return false, errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/diaries/%s/keys/envelopes", c.baseURL, diaryID)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodPut, url, request)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create request")
+	}
+
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal request")
+	}
+
+	signature := signBytes(requestJSON, c.credentials.SigningPrivateKey)
+	req.Header.Set("X-Signature", base64.StdEncoding.EncodeToString(signature))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, ErrDiaryNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return false, ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return false, errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	return false, nil
+}
+
+// commitAccountKeys registers the new public keys with the server. The request is
+// signed with the current signing key and carries a proof made with the new one.
+func (c *Client) commitAccountKeys(ctx context.Context, newCredentials *Credentials) error {
+	proof := accountKeyRotationProof(
+		c.credentials.SigningPublicKey,
+		newCredentials.SigningPublicKey,
+		newCredentials.EncryptionPublicKey,
+	)
+
+	request := openapi.RotateAccountKeysRequest{
+		EncryptionPublicKey: newCredentials.EncryptionPublicKey,
+		SignaturePublicKey:  newCredentials.SigningPublicKey,
+		SignatureProof:      signBytes(proof, newCredentials.SigningPrivateKey),
+	}
+
+	// Validate request before sending
+	if err := request.Validate(); err != nil {
+		return errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/account/keys", c.baseURL)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodPut, url, request)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request")
+	}
+
+	signature := signBytes(requestJSON, c.credentials.SigningPrivateKey)
+	req.Header.Set("X-Signature", base64.StdEncoding.EncodeToString(signature))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return errors.New("not every diary key was re-encrypted for the new account keys")
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// accountKeyRotationProof builds the message signed with the new signing key,
+// binding it to the key it replaces
+func accountKeyRotationProof(oldSigningPublicKey, newSigningPublicKey, newEncryptionPublicKey []byte) []byte {
+	proof := []byte("thingsdiary-account-key-rotation")
+	proof = append(proof, oldSigningPublicKey...)
+	proof = append(proof, newSigningPublicKey...)
+	proof = append(proof, newEncryptionPublicKey...)
+
+	return proof
+}
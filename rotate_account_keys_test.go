@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestAccount_RotateAccountKeys() {
+	t := s.T()
+	ctx := context.Background()
+
+	newSeedPhrase := fmt.Sprint([]string{
+		"hollow", "ribbon", "gravel", "summit",
+		"pepper", "falcon", "marble", "orchid",
+		"timber", "velvet", "anchor", "comet",
+	})
+
+	// Arrange
+	var login = fmt.Sprintf("test-rotate-account-keys-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Exposed Diary"})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Still readable"})
+	require.NoError(t, err)
+
+	// Act
+	err = s.client.RotateAccountKeys(ctx, newSeedPhrase)
+	require.NoError(t, err)
+
+	// Assert: Client switched to the new keys and still reads existing content
+	newCredentials, err := NewCredentials(newSeedPhrase)
+	require.NoError(t, err)
+	assert.Equal(t, newCredentials.EncryptionPublicKey, s.client.credentials.EncryptionPublicKey)
+
+	gotEntry, err := s.client.GetEntryByID(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Still readable", gotEntry.Content)
+
+	// Assert: Running it again is a no-op
+	err = s.client.RotateAccountKeys(ctx, newSeedPhrase)
+	require.NoError(t, err)
+
+	// Assert: Only the new seed phrase authenticates
+	err = NewClient(WithBaseURL("http://localhost:8081/api")).Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.Error(t, err)
+
+	err = NewClient(WithBaseURL("http://localhost:8081/api")).Authenticate(ctx, login, "password-123", newSeedPhrase)
+	require.NoError(t, err)
+}
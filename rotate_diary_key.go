@@ -0,0 +1,129 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// RotateDiaryKey replaces the active diary key with a new one sealed to every
+// current member and enrolled device. Call it to finish a revocation whose key
+// rotation failed with ErrKeyRotationFailed.
+func (c *Client) RotateDiaryKey(ctx context.Context, diaryID string) error {
+	if c.credentials == nil {
+		return ErrUnauthorized
+	}
+
+	_, err := c.rotateDiaryKey(ctx, diaryID)
+
+	return err
+}
+
+// rotateDiaryKey replaces the active diary key with a freshly generated one sealed
+// to every current member and enrolled device. Existing entities keep referencing
+// the previous key, which stays readable through the diary keyring.
+func (c *Client) rotateDiaryKey(ctx context.Context, diaryID string) (*openapi.DiaryEncryptionKey, error) {
+	members, err := c.getDiaryMembers(ctx, diaryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get diary members")
+	}
+
+	// Enrolled devices hold their own key pairs and need the new key as well
+	devices, err := c.getDevices(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get devices")
+	}
+
+	recipients := make([][]byte, 0, len(members)+len(devices)+1)
+	addRecipient := func(publicKey []byte) {
+		for _, recipient := range recipients {
+			if bytes.Equal(recipient, publicKey) {
+				return
+			}
+		}
+
+		recipients = append(recipients, publicKey)
+	}
+
+	addRecipient(c.credentials.EncryptionPublicKey)
+	for _, member := range members {
+		addRecipient(member.EncryptionPublicKey)
+	}
+	for _, device := range devices {
+		addRecipient(device.EncryptionPublicKey)
+	}
+
+	// Generate new diary key
+	diaryKey, err := generateSymmetricKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate diary key")
+	}
+
+	encryptedDiaryKeys := make([]openapi.DiaryKeyRecipient, 0, len(recipients))
+	for _, publicKey := range recipients {
+		encryptedDiaryKey, err := encryptWithPublicKey(diaryKey, publicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encrypt diary key")
+		}
+
+		encryptedDiaryKeys = append(encryptedDiaryKeys, openapi.DiaryKeyRecipient{
+			EncryptionPublicKey: publicKey,
+			Value:               encryptedDiaryKey,
+		})
+	}
+
+	request := openapi.RotateDiaryKeyRequest{
+		EncryptedDiaryKeys: encryptedDiaryKeys,
+	}
+
+	// Validate request before sending
+	if err := request.Validate(); err != nil {
+		return nil, errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/diaries/%s/keys", c.baseURL, diaryID)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodPost, url, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+
+	signature := signBytes(requestJSON, c.credentials.SigningPrivateKey)
+	req.Header.Set("X-Signature", base64.StdEncoding.EncodeToString(signature))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDiaryNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	var apiResponse openapi.RotateDiaryKeyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response")
+	}
+
+	return &apiResponse.Key, nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// ShareDiary grants the account owning recipientPublicKey access to a diary.
+// Every diary key is sealed to the recipient's X25519 public key, so the
+// server never sees the diary keys in plaintext.
+func (c *Client) ShareDiary(ctx context.Context, diaryID string, recipientPublicKey []byte, role DiaryRole) (*DiaryMember, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	// Get encryption keys
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get diary keys")
+	}
+
+	// Seal every diary key so the recipient can read entities written before a rotation
+	envelopes := make([]openapi.DiaryKeyEnvelope, 0, len(keyring.keys))
+	for keyID, diaryKey := range keyring.keys {
+		encryptedDiaryKey, err := encryptWithPublicKey(diaryKey, recipientPublicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encrypt diary key")
+		}
+
+		envelopes = append(envelopes, openapi.DiaryKeyEnvelope{
+			DiaryKeyId: keyID,
+			Value:      encryptedDiaryKey,
+		})
+	}
+
+	request := openapi.ShareDiaryRequest{
+		EncryptionPublicKey: recipientPublicKey,
+		Role:                openapi.DiaryMemberRole(role),
+		EncryptedDiaryKeys:  envelopes,
+	}
+
+	// Validate request before sending
+	if err := request.Validate(); err != nil {
+		return nil, errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/diaries/%s/members", c.baseURL, diaryID)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodPost, url, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+
+	signature := signBytes(requestJSON, c.credentials.SigningPrivateKey)
+	req.Header.Set("X-Signature", base64.StdEncoding.EncodeToString(signature))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		var errorResp openapi.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil {
+			if errorResp.ErrorCode == openapi.ResponseErrorCodeAccountNotFound {
+				return nil, ErrAccountNotFound
+			}
+		}
+
+		return nil, ErrDiaryNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, ErrForbidden
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return nil, ErrMemberAlreadyExists
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	var apiResponse openapi.ShareDiaryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response")
+	}
+
+	return convertDiaryMember(&apiResponse.Member), nil
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerMember registers a second account with its own seed phrase and returns an authenticated client for it
+func (s *ClientSuite) registerMember(ctx context.Context, loginPrefix string) (*Client, *Credentials) {
+	t := s.T()
+
+	memberSeedPhrase := fmt.Sprint([]string{
+		"violet", "harbor", "pencil", "saddle",
+		"meadow", "copper", "lantern", "orbit",
+		"walnut", "thunder", "velvet", "quartz",
+	})
+
+	var login = fmt.Sprintf("%s-%d@thingsdiary.io", loginPrefix, time.Now().UnixMilli())
+	memberClient := NewClient(WithBaseURL("http://localhost:8081/api"))
+
+	err := memberClient.Register(ctx, login, "password-123", memberSeedPhrase)
+	require.NoError(t, err)
+
+	err = memberClient.Authenticate(ctx, login, "password-123", memberSeedPhrase)
+	require.NoError(t, err)
+
+	memberCredentials, err := NewCredentials(memberSeedPhrase)
+	require.NoError(t, err)
+
+	return memberClient, memberCredentials
+}
+
+func (s *ClientSuite) TestDiary_ShareDiary() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: Register owner and member
+	var login = fmt.Sprintf("test-share-diary-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	memberClient, memberCredentials := s.registerMember(ctx, "test-share-diary-member")
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{
+		Title:       "Family Diary",
+		Description: "Shared with the family",
+	})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{
+		Content: "Written before sharing",
+	})
+	require.NoError(t, err)
+
+	// Act
+	member, err := s.client.ShareDiary(ctx, diary.ID, memberCredentials.EncryptionPublicKey, DiaryRoleEditor)
+
+	// Assert: Member was added
+	require.NoError(t, err)
+	require.NotNil(t, member)
+	assert.NotEmpty(t, member.AccountID)
+	assert.Equal(t, DiaryRoleEditor, member.Role)
+	assert.Equal(t, memberCredentials.EncryptionPublicKey, member.EncryptionPublicKey)
+
+	// Assert: Member can decrypt the diary and existing entries
+	sharedDiary, err := memberClient.GetDiaryByID(ctx, diary.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Family Diary", sharedDiary.Title)
+
+	sharedEntry, err := memberClient.GetEntryByID(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Written before sharing", sharedEntry.Content)
+}
+
+func (s *ClientSuite) TestDiary_ShareDiary_AlreadyMember() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-share-diary-twice-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	_, memberCredentials := s.registerMember(ctx, "test-share-diary-twice-member")
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Family Diary"})
+	require.NoError(t, err)
+
+	_, err = s.client.ShareDiary(ctx, diary.ID, memberCredentials.EncryptionPublicKey, DiaryRoleViewer)
+	require.NoError(t, err)
+
+	// Act
+	_, err = s.client.ShareDiary(ctx, diary.ID, memberCredentials.EncryptionPublicKey, DiaryRoleViewer)
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrMemberAlreadyExists)
+}
+
+func (s *ClientSuite) TestDiary_ShareDiary_Unauthorized() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Act: Try to share diary without authentication
+	member, err := s.client.ShareDiary(ctx, "diary-id", make([]byte, 32), DiaryRoleViewer)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, member)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
@@ -0,0 +1,399 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// Sync sends the queued changes of every stored diary in order and then pulls the
+// remote state of every diary the account can access.
+//
+// Queued changes carry the version of the moment they were made. An entry changed
+// remotely in the meantime is passed to the resolver set with WithEntryResolver,
+// while for topics and templates the remote change wins. Sending stops at the
+// first failing operation, which stays queued together with the ones after it.
+// Local entities with queued changes are not overwritten by the pull.
+//
+// Diaries the account lost access to are removed from the store. When they had
+// queued changes, Sync completes and returns a *DiscardedChangesError holding them.
+func (o *OfflineClient) Sync(ctx context.Context) error {
+	if o.client.credentials == nil {
+		return ErrUnauthorized
+	}
+
+	remoteDiaries, err := o.client.getDiaries(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get diaries")
+	}
+
+	remote := make(map[string]*openapi.Diary, len(remoteDiaries))
+	for _, diary := range remoteDiaries {
+		remote[diary.Id] = diary
+	}
+
+	diaryIDs, err := o.store.DiaryIDs()
+	if err != nil {
+		return err
+	}
+
+	discarded := map[string][]OutboxOperation{}
+	for _, diaryID := range diaryIDs {
+		if _, ok := remote[diaryID]; !ok {
+			pending, err := o.store.Pending(diaryID)
+			if err != nil {
+				return err
+			}
+
+			if err := o.store.remove(diaryID); err != nil {
+				return err
+			}
+
+			if len(pending) > 0 {
+				discarded[diaryID] = pending
+			}
+
+			continue
+		}
+
+		if err := o.push(ctx, diaryID); err != nil {
+			return errors.Wrapf(err, "failed to push changes of diary %s", diaryID)
+		}
+	}
+
+	for _, diary := range remoteDiaries {
+		if err := o.pull(ctx, diary); err != nil {
+			return errors.Wrapf(err, "failed to pull diary %s", diary.Id)
+		}
+	}
+
+	if len(discarded) > 0 {
+		return &DiscardedChangesError{Operations: discarded}
+	}
+
+	return nil
+}
+
+// DiscardedChangesError reports queued changes of diaries the account lost access
+// to, keyed by diary ID. It matches ErrChangesDiscarded with errors.Is.
+type DiscardedChangesError struct {
+	Operations map[string][]OutboxOperation
+}
+
+func (e *DiscardedChangesError) Error() string {
+	count := 0
+	for _, operations := range e.Operations {
+		count += len(operations)
+	}
+
+	return fmt.Sprintf("%d queued changes of %d inaccessible diaries were discarded", count, len(e.Operations))
+}
+
+func (e *DiscardedChangesError) Unwrap() error {
+	return ErrChangesDiscarded
+}
+
+// push sends the outbox of a diary, oldest operation first
+func (o *OfflineClient) push(ctx context.Context, diaryID string) error {
+	for {
+		var sent OutboxOperation
+		queued := false
+		err := o.store.view(diaryID, func(d *localDiary) error {
+			if len(d.Outbox) > 0 {
+				sent, queued = d.Outbox[0], true
+			}
+			return nil
+		})
+		if err != nil || !queued {
+			return err
+		}
+
+		apply, err := o.send(ctx, diaryID, sent)
+		if err != nil {
+			return errors.Wrapf(err, "failed to send %s of %s", sent.Kind, sent.EntityID)
+		}
+
+		err = o.store.update(diaryID, func(d *localDiary) error {
+			// The operation may have been superseded while it was being sent
+			for i, queued := range d.Outbox {
+				if queued.Kind == sent.Kind && queued.EntityID == sent.EntityID && queued.QueuedAt.Equal(sent.QueuedAt) {
+					d.Outbox = append(d.Outbox[:i], d.Outbox[i+1:]...)
+
+					if apply != nil && !d.hasPending(sent.Kind, sent.EntityID) {
+						apply(d)
+					}
+					break
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// send performs an outbox operation and returns how to store the server response.
+// Put requests are stamped with a new version when they are sent: the version taken
+// when the change was queued is older than changes made elsewhere in the meantime,
+// and Changes would skip the change.
+func (o *OfflineClient) send(ctx context.Context, diaryID string, op OutboxOperation) (func(d *localDiary), error) {
+	switch op.Kind {
+	case OutboxPutEntry:
+		entry, err := o.sendPutEntry(ctx, diaryID, op)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(d *localDiary) { d.Entries[entry.Id] = entry }, nil
+	case OutboxPutTopic:
+		topic, err := o.sendPutTopic(ctx, diaryID, op)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(d *localDiary) { d.Topics[topic.Id] = topic }, nil
+	case OutboxPutTemplate:
+		template, err := o.sendPutTemplate(ctx, diaryID, op)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(d *localDiary) { d.Templates[template.Id] = template }, nil
+	case OutboxDeleteEntry:
+		err := o.client.DeleteEntry(ctx, diaryID, op.EntityID)
+		if errors.Is(err, ErrEntryNotFound) {
+			err = nil
+		}
+
+		return nil, err
+	case OutboxDeleteTopic:
+		err := o.client.deleteTopic(ctx, diaryID, op.EntityID, DeleteTopicParams{DeleteEntries: op.DeleteEntries})
+		if errors.Is(err, ErrTopicNotFound) {
+			err = nil
+		}
+
+		return nil, err
+	case OutboxDeleteTemplate:
+		err := o.client.DeleteTemplate(ctx, diaryID, op.EntityID)
+		if errors.Is(err, ErrTemplateNotFound) {
+			err = nil
+		}
+
+		return nil, err
+	}
+
+	return nil, errors.Errorf("unknown outbox operation %q", op.Kind)
+}
+
+// sendPutEntry sends a queued entry change. A remote change made while it was
+// queued would be silently overwritten, so the remote version is compared with the
+// one the change started from, and any difference is resolved as a conflict.
+func (o *OfflineClient) sendPutEntry(ctx context.Context, diaryID string, op OutboxOperation) (*openapi.Entry, error) {
+	remote, err := o.client.getEntry(ctx, diaryID, op.EntityID)
+	if err != nil && !errors.Is(err, ErrEntryNotFound) {
+		return nil, errors.Wrap(err, "failed to get remote entry")
+	}
+
+	if remote != nil && (op.Base == nil || remote.Version != op.Base.Version) {
+		return o.resolveEntryConflict(ctx, diaryID, op, remote)
+	}
+
+	request := *op.Entry
+	request.Version = NewVersion()
+
+	entry, err := o.client.sendPutEntry(ctx, diaryID, op.EntityID, &request)
+	if !errors.Is(err, ErrVersionConflict) {
+		return entry, err
+	}
+
+	// Changed between reading and writing
+	remote, err = o.client.getEntry(ctx, diaryID, op.EntityID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get remote entry")
+	}
+
+	return o.resolveEntryConflict(ctx, diaryID, op, remote)
+}
+
+// sendPutTopic sends a queued topic change unless the remote topic was changed
+// after it, in which case the remote topic is kept
+func (o *OfflineClient) sendPutTopic(ctx context.Context, diaryID string, op OutboxOperation) (*openapi.Topic, error) {
+	remote, err := o.client.getTopic(ctx, diaryID, op.EntityID)
+	if err != nil && !errors.Is(err, ErrTopicNotFound) {
+		return nil, errors.Wrap(err, "failed to get remote topic")
+	}
+
+	if remote != nil && remote.Version > op.Topic.Version {
+		return remote, nil
+	}
+
+	request := *op.Topic
+	request.Version = NewVersion()
+
+	topic, err := o.client.sendPutTopic(ctx, diaryID, op.EntityID, &request)
+	if errors.Is(err, ErrVersionConflict) {
+		return o.client.getTopic(ctx, diaryID, op.EntityID)
+	}
+
+	return topic, err
+}
+
+// sendPutTemplate sends a queued template change unless the remote template was
+// changed after it, in which case the remote template is kept
+func (o *OfflineClient) sendPutTemplate(ctx context.Context, diaryID string, op OutboxOperation) (*openapi.Template, error) {
+	remote, err := o.client.getTemplate(ctx, diaryID, op.EntityID)
+	if err != nil && !errors.Is(err, ErrTemplateNotFound) {
+		return nil, errors.Wrap(err, "failed to get remote template")
+	}
+
+	if remote != nil && remote.Version > op.Template.Version {
+		return remote, nil
+	}
+
+	request := *op.Template
+	request.Version = NewVersion()
+
+	template, err := o.client.sendPutTemplate(ctx, diaryID, op.EntityID, &request)
+	if errors.Is(err, ErrVersionConflict) {
+		return o.client.getTemplate(ctx, diaryID, op.EntityID)
+	}
+
+	return template, err
+}
+
+// resolveEntryConflict resolves a queued entry change against a different remote
+// version and returns the entry stored on the server afterwards
+func (o *OfflineClient) resolveEntryConflict(ctx context.Context, diaryID string, op OutboxOperation, remoteData *openapi.Entry) (*openapi.Entry, error) {
+	keyring, err := o.client.getDiaryKeyring(ctx, diaryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get diary keys")
+	}
+
+	decrypt := func(apiEntry *openapi.Entry) (*Entry, error) {
+		diaryKey, err := keyring.get(apiEntry.Encryption.DiaryKeyId)
+		if err != nil {
+			return nil, err
+		}
+
+		return o.client.decryptEntry(apiEntry, diaryKey)
+	}
+
+	remote, err := decrypt(remoteData)
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := decrypt(entryFromPutRequest(diaryID, op.EntityID, op.Entry, op.QueuedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	var base *Entry
+	if op.Base != nil {
+		if base, err = decrypt(op.Base); err != nil {
+			return nil, err
+		}
+	}
+
+	conflict := EntryConflict{
+		DiaryID:      diaryID,
+		EntryID:      op.EntityID,
+		Base:         base,
+		Local:        local.PutParams(),
+		LocalVersion: op.Entry.Version,
+		Remote:       remote,
+	}
+
+	if _, err := o.client.applyEntryResolution(ctx, conflict, o.resolve); err != nil {
+		return nil, err
+	}
+
+	return o.client.getEntry(ctx, diaryID, op.EntityID)
+}
+
+// pull replaces the stored state of a diary with the remote one, keeping entities
+// with queued changes
+func (o *OfflineClient) pull(ctx context.Context, diary *openapi.Diary) error {
+	keys, err := o.client.getDiaryKeys(ctx, diary.Id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get diary keys")
+	}
+
+	entries, err := o.client.getEntries(ctx, diary.Id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get entries")
+	}
+
+	topics, err := o.client.getTopics(ctx, diary.Id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get topics")
+	}
+
+	templates, err := o.client.getTemplates(ctx, diary.Id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get templates")
+	}
+
+	return o.store.update(diary.Id, func(d *localDiary) error {
+		d.Diary = diary
+		d.Keys = keys
+
+		mergeRemote(d.Entries, entries,
+			func(e *openapi.Entry) string { return e.Id },
+			func(e *openapi.Entry) uint64 { return e.Version },
+			func(id string) bool { return d.hasPending(OutboxPutEntry, id) },
+		)
+		mergeRemote(d.Topics, topics,
+			func(t *openapi.Topic) string { return t.Id },
+			func(t *openapi.Topic) uint64 { return t.Version },
+			func(id string) bool { return d.hasPending(OutboxPutTopic, id) },
+		)
+		mergeRemote(d.Templates, templates,
+			func(t *openapi.Template) string { return t.Id },
+			func(t *openapi.Template) uint64 { return t.Version },
+			func(id string) bool { return d.hasPending(OutboxPutTemplate, id) },
+		)
+
+		d.SyncedAt = time.Now().UTC()
+
+		return nil
+	})
+}
+
+// mergeRemote updates local with remote entities that are not pending and not
+// older than the stored ones. Entities missing remotely are dropped unless pending.
+func mergeRemote[T any](
+	local map[string]*T,
+	remote []*T,
+	id func(*T) string,
+	version func(*T) uint64,
+	pending func(id string) bool,
+) {
+	seen := make(map[string]bool, len(remote))
+	for _, item := range remote {
+		itemID := id(item)
+		seen[itemID] = true
+
+		if pending(itemID) {
+			continue
+		}
+
+		if stored, ok := local[itemID]; ok && version(stored) > version(item) {
+			continue
+		}
+
+		local[itemID] = item
+	}
+
+	for itemID := range local {
+		if !seen[itemID] && !pending(itemID) {
+			delete(local, itemID)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestOffline_Sync() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-offline-sync-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	remoteEntry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Written online"})
+	require.NoError(t, err)
+
+	offline := NewOfflineClient(s.client, NewLocalStore(t.TempDir()))
+	require.NoError(t, offline.Sync(ctx))
+
+	// Act: Change things offline, then sync
+	topic, err := offline.PutTopic(ctx, diary.ID, uuid.NewString(), PutTopicParams{Title: "Plane"})
+	require.NoError(t, err)
+
+	params := remoteEntry.PutParams()
+	params.Content = "Edited offline"
+	_, err = offline.PutEntry(ctx, diary.ID, remoteEntry.ID, params)
+	require.NoError(t, err)
+
+	pending, err := offline.Store().Pending(diary.ID)
+	require.NoError(t, err)
+
+	localEntries, err := offline.GetEntries(ctx, diary.ID)
+	require.NoError(t, err)
+
+	err = offline.Sync(ctx)
+	require.NoError(t, err)
+
+	pendingAfterSync, err := offline.Store().Pending(diary.ID)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Len(t, pending, 2)
+	require.Len(t, localEntries, 1)
+	assert.Equal(t, "Edited offline", localEntries[0].Content)
+	assert.Empty(t, pendingAfterSync)
+
+	gotEntry, err := s.client.GetEntryByID(ctx, diary.ID, remoteEntry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Edited offline", gotEntry.Content)
+
+	gotTopic, err := s.client.GetTopicByID(ctx, diary.ID, topic.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Plane", gotTopic.Title)
+}
+
+func (s *ClientSuite) TestOffline_Sync_ReportsDiscardedChanges() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: A member queues a change to a shared diary, then loses access
+	var login = fmt.Sprintf("test-offline-sync-discarded-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	memberClient, memberCredentials := s.registerMember(ctx, "test-offline-sync-discarded-member")
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Family Diary"})
+	require.NoError(t, err)
+
+	member, err := s.client.ShareDiary(ctx, diary.ID, memberCredentials.EncryptionPublicKey, DiaryRoleEditor)
+	require.NoError(t, err)
+
+	offline := NewOfflineClient(memberClient, NewLocalStore(t.TempDir()))
+	require.NoError(t, offline.Sync(ctx))
+
+	_, err = offline.PutEntry(ctx, diary.ID, uuid.NewString(), PutEntryParams{Content: "Written offline"})
+	require.NoError(t, err)
+
+	err = s.client.RevokeMember(ctx, diary.ID, member.AccountID)
+	require.NoError(t, err)
+
+	// Act
+	err = offline.Sync(ctx)
+
+	// Assert
+	require.ErrorIs(t, err, ErrChangesDiscarded)
+
+	var discardedErr *DiscardedChangesError
+	require.True(t, errors.As(err, &discardedErr))
+	require.Len(t, discardedErr.Operations[diary.ID], 1)
+	assert.Equal(t, OutboxPutEntry, discardedErr.Operations[diary.ID][0].Kind)
+
+	pending, err := offline.Store().Pending(diary.ID)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func (s *ClientSuite) TestOffline_Sync_ResolvesEarlierRemoteChange() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-offline-sync-resolve-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	remoteEntry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Written online"})
+	require.NoError(t, err)
+
+	var conflicts []EntryConflict
+	resolver := func(conflict EntryConflict) (EntryResolution, error) {
+		conflicts = append(conflicts, conflict)
+		return KeepBoth(conflict)
+	}
+
+	offline := NewOfflineClient(s.client, NewLocalStore(t.TempDir()), WithEntryResolver(resolver))
+	require.NoError(t, offline.Sync(ctx))
+
+	// Act: The remote change is made before the offline one, so it has the older version
+	onlineParams := remoteEntry.PutParams()
+	onlineParams.Content = "Edited online"
+	_, err = s.client.PutEntry(ctx, diary.ID, remoteEntry.ID, onlineParams)
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+
+	offlineParams := remoteEntry.PutParams()
+	offlineParams.Content = "Edited offline"
+	_, err = offline.PutEntry(ctx, diary.ID, remoteEntry.ID, offlineParams)
+	require.NoError(t, err)
+
+	err = offline.Sync(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "Written online", conflicts[0].Base.Content)
+	assert.Equal(t, "Edited offline", conflicts[0].Local.Content)
+	assert.Equal(t, "Edited online", conflicts[0].Remote.Content)
+
+	gotEntry, err := s.client.GetEntryByID(ctx, diary.ID, remoteEntry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Edited online", gotEntry.Content)
+
+	entries, err := s.client.GetEntries(ctx, diary.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func (s *ClientSuite) TestOffline_Sync_ChangesReportLateChange() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-offline-sync-changes-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	offline := NewOfflineClient(s.client, NewLocalStore(t.TempDir()))
+	require.NoError(t, offline.Sync(ctx))
+
+	offlineEntry, err := offline.PutEntry(ctx, diary.ID, uuid.NewString(), PutEntryParams{Content: "Written offline"})
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+
+	_, err = s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Written online later"})
+	require.NoError(t, err)
+
+	seen, err := s.client.Changes(ctx, diary.ID, 0)
+	require.NoError(t, err)
+
+	// Act: The offline entry reaches the server after the reader caught up
+	err = offline.Sync(ctx)
+	require.NoError(t, err)
+
+	changes, err := s.client.Changes(ctx, diary.ID, seen.Version)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, changes.Changes, 1)
+	assert.Equal(t, offlineEntry.ID, changes.Changes[0].Entry.ID)
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/samber/mo"
@@ -8,16 +10,67 @@ import (
 
 // Template represents a plaintext template that users work with
 type Template struct {
-	ID        string
-	DiaryID   string
-	Content   string
+	ID          string
+	DiaryID     string
+	Name        string
+	Description string
+	Icon        string
+	Color       string
+	Content     string
+
+	// Prompts are guided questions answered when writing an entry, in order
+	Prompts []string
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt mo.Option[time.Time]
 	Version   uint64
+
+	// unknown holds details fields written by newer clients
+	unknown unknownFields
 }
 
 // TemplateDetails represents the plaintext content structure for template details
 type TemplateDetails struct {
-	Content string `json:"content"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Icon        string   `json:"icon,omitempty"`
+	Color       string   `json:"color,omitempty"`
+	Content     string   `json:"content"`
+	Prompts     []string `json:"prompts,omitempty"`
+
+	unknown unknownFields
+}
+
+func (d TemplateDetails) MarshalJSON() ([]byte, error) {
+	type plain TemplateDetails
+	return marshalWithUnknown(plain(d), d.unknown)
+}
+
+func (d *TemplateDetails) UnmarshalJSON(data []byte) error {
+	type plain TemplateDetails
+
+	var p plain
+	unknown, err := unmarshalWithUnknown(data, &p)
+	if err != nil {
+		return err
+	}
+
+	*d = TemplateDetails(p)
+	d.unknown = unknown
+
+	return nil
+}
+
+// PutParams returns the parameters that store the template unchanged with PutTemplate
+func (t *Template) PutParams() PutTemplateParams {
+	return PutTemplateParams{
+		Name:        t.Name,
+		Description: t.Description,
+		Icon:        t.Icon,
+		Color:       t.Color,
+		Content:     t.Content,
+		Prompts:     slices.Clone(t.Prompts),
+		unknown:     maps.Clone(t.unknown),
+	}
 }
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ContentFromAnswers turns answers to the template prompts into entry content.
+// answers[i] answers Prompts[i]; every answered prompt becomes a Markdown heading
+// followed by its answer, while empty answers are left out.
+func (t *Template) ContentFromAnswers(answers []string) (string, error) {
+	if len(answers) > len(t.Prompts) {
+		return "", errors.Errorf("got %d answers for %d prompts", len(answers), len(t.Prompts))
+	}
+
+	sections := make([]string, 0, len(answers))
+	for i, answer := range answers {
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			continue
+		}
+
+		sections = append(sections, "## "+t.Prompts[i]+"\n\n"+answer)
+	}
+
+	return strings.Join(sections, "\n\n"), nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_ContentFromAnswers(t *testing.T) {
+	template := Template{
+		Prompts: []string{
+			"What went well?",
+			"What could be better?",
+			"What is next?",
+		},
+	}
+
+	t.Run("answered prompts", func(t *testing.T) {
+		content, err := template.ContentFromAnswers([]string{"Shipped the release", " ", "Rest "})
+
+		require.NoError(t, err)
+		assert.Equal(t, "## What went well?\n\nShipped the release\n\n## What is next?\n\nRest", content)
+	})
+
+	t.Run("no answers", func(t *testing.T) {
+		content, err := template.ContentFromAnswers(nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, content)
+	})
+
+	t.Run("too many answers", func(t *testing.T) {
+		_, err := template.ContentFromAnswers([]string{"a", "b", "c", "d"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSortTemplatesByName(t *testing.T) {
+	templates := []*Template{
+		{ID: "4", Name: ""},
+		{ID: "3", Name: "gratitude"},
+		{ID: "2", Name: "Daily review"},
+		{ID: "1", Name: "Gratitude"},
+	}
+
+	sortTemplatesByName(templates)
+
+	var ids []string
+	for _, template := range templates {
+		ids = append(ids, template.ID)
+	}
+
+	assert.Equal(t, []string{"2", "1", "3", "4"}, ids)
+}
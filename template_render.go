@@ -0,0 +1,275 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Template content is plain text with tags in double braces:
+//
+//	{{name}}                       value of variable name, empty when not set
+//	{{date}} / {{date "Jan 2"}}    current date, optionally with a Go time layout
+//	{{time}} / {{time "3:04PM"}}   current time, optionally with a Go time layout
+//	{{weekday}}                    current day of the week
+//	{{prompt}}                     a writing prompt that changes once a day
+//	{{#if name}}...{{else}}...{{/if}}
+//
+// A condition holds when the variable or builtin is not empty. Variables take
+// precedence over builtins with the same name.
+const (
+	templateDateLayout = "2006-01-02"
+	templateTimeLayout = "15:04"
+)
+
+// templatePrompts are the writing prompts rendered by {{prompt}}
+var templatePrompts = []string{
+	"What made you smile today?",
+	"What are you grateful for right now?",
+	"What is on your mind that you have not said out loud?",
+	"What did you learn today?",
+	"What would make tomorrow a good day?",
+	"Who did you think about today, and why?",
+	"What drained your energy today, and what restored it?",
+	"Describe a small moment you want to remember.",
+	"What are you looking forward to?",
+	"What is something you would like to let go of?",
+}
+
+// RenderTemplate renders template content with the given variables at the current time
+func RenderTemplate(content string, vars map[string]string) (string, error) {
+	return renderTemplate(content, vars, time.Now())
+}
+
+// renderTemplate renders template content with the given variables at now
+func renderTemplate(content string, vars map[string]string, now time.Time) (string, error) {
+	nodes, err := parseTemplate(content)
+	if err != nil {
+		return "", err
+	}
+
+	scope := templateScope{vars: vars, now: now}
+
+	var sb strings.Builder
+	for _, node := range nodes {
+		node.render(&sb, &scope)
+	}
+
+	return sb.String(), nil
+}
+
+// templateScope resolves variables and builtins while rendering
+type templateScope struct {
+	vars map[string]string
+	now  time.Time
+}
+
+// lookup returns the value of a variable or builtin, formatted with layout when given
+func (s *templateScope) lookup(name, layout string) string {
+	if value, ok := s.vars[name]; ok {
+		return value
+	}
+
+	switch name {
+	case "date":
+		if layout == "" {
+			layout = templateDateLayout
+		}
+
+		return s.now.Format(layout)
+	case "time":
+		if layout == "" {
+			layout = templateTimeLayout
+		}
+
+		return s.now.Format(layout)
+	case "weekday":
+		return s.now.Weekday().String()
+	case "prompt":
+		return dailyPrompt(s.now)
+	}
+
+	return ""
+}
+
+// dailyPrompt picks a writing prompt that stays the same for a calendar day
+func dailyPrompt(now time.Time) string {
+	day := now.YearDay() + now.Year()*366
+
+	return templatePrompts[day%len(templatePrompts)]
+}
+
+// templateNode is a parsed part of a template
+type templateNode interface {
+	render(sb *strings.Builder, scope *templateScope)
+}
+
+type templateText string
+
+func (n templateText) render(sb *strings.Builder, _ *templateScope) {
+	sb.WriteString(string(n))
+}
+
+type templateVariable struct {
+	name   string
+	layout string
+}
+
+func (n templateVariable) render(sb *strings.Builder, scope *templateScope) {
+	sb.WriteString(scope.lookup(n.name, n.layout))
+}
+
+type templateIf struct {
+	name      string
+	then      []templateNode
+	otherwise []templateNode
+}
+
+func (n templateIf) render(sb *strings.Builder, scope *templateScope) {
+	nodes := n.otherwise
+	if scope.lookup(n.name, "") != "" {
+		nodes = n.then
+	}
+
+	for _, node := range nodes {
+		node.render(sb, scope)
+	}
+}
+
+// parseTemplate parses template content into nodes
+func parseTemplate(content string) ([]templateNode, error) {
+	p := templateParser{rest: content}
+
+	nodes, end, err := p.parseNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if end != "" {
+		return nil, errors.Errorf("template: unexpected {{%s}}", end)
+	}
+
+	return nodes, nil
+}
+
+type templateParser struct {
+	rest string
+}
+
+// parseNodes parses nodes until the end of the content or an {{else}} or {{/if}}
+// tag, which is returned without being consumed further
+func (p *templateParser) parseNodes() ([]templateNode, string, error) {
+	var nodes []templateNode
+
+	for p.rest != "" {
+		start := strings.Index(p.rest, "{{")
+		if start < 0 {
+			nodes = append(nodes, templateText(p.rest))
+			p.rest = ""
+			break
+		}
+
+		if start > 0 {
+			nodes = append(nodes, templateText(p.rest[:start]))
+		}
+
+		end := strings.Index(p.rest[start:], "}}")
+		if end < 0 {
+			return nil, "", errors.New("template: unclosed {{")
+		}
+
+		tag := strings.TrimSpace(p.rest[start+2 : start+end])
+		p.rest = p.rest[start+end+2:]
+
+		switch {
+		case tag == "else" || tag == "/if":
+			return nodes, tag, nil
+		case strings.HasPrefix(tag, "#if"):
+			node, err := p.parseIf(strings.TrimSpace(strings.TrimPrefix(tag, "#if")))
+			if err != nil {
+				return nil, "", err
+			}
+
+			nodes = append(nodes, node)
+		default:
+			node, err := parseTemplateVariable(tag)
+			if err != nil {
+				return nil, "", err
+			}
+
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes, "", nil
+}
+
+// parseIf parses the body of an {{#if name}} tag up to the matching {{/if}}
+func (p *templateParser) parseIf(name string) (templateNode, error) {
+	if !isTemplateName(name) {
+		return nil, errors.Errorf("template: invalid condition %q", name)
+	}
+
+	node := templateIf{name: name}
+
+	then, end, err := p.parseNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	node.then = then
+
+	if end == "else" {
+		otherwise, elseEnd, err := p.parseNodes()
+		if err != nil {
+			return nil, err
+		}
+
+		node.otherwise = otherwise
+		end = elseEnd
+	}
+
+	if end != "/if" {
+		return nil, errors.Errorf("template: {{#if %s}} is not closed", name)
+	}
+
+	return node, nil
+}
+
+// parseTemplateVariable parses "name" or `name "layout"`
+func parseTemplateVariable(tag string) (templateNode, error) {
+	name, arg, hasArg := strings.Cut(tag, " ")
+	if !isTemplateName(name) {
+		return nil, errors.Errorf("template: invalid tag {{%s}}", tag)
+	}
+
+	node := templateVariable{name: name}
+
+	if hasArg {
+		layout, err := strconv.Unquote(strings.TrimSpace(arg))
+		if err != nil {
+			return nil, errors.Errorf("template: argument of {{%s}} must be a quoted string", name)
+		}
+
+		node.layout = layout
+	}
+
+	return node, nil
+}
+
+// isTemplateName reports whether name is a valid variable name
+func isTemplateName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		if !(r == '_' || r == '-' || r == '.' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
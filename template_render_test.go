@@ -0,0 +1,121 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	now := time.Date(2024, 5, 17, 8, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		content  string
+		vars     map[string]string
+		expected string
+	}{
+		{
+			name:     "plain text",
+			content:  "No tags here",
+			expected: "No tags here",
+		},
+		{
+			name:     "builtins",
+			content:  "{{weekday}}, {{date}} at {{time}}",
+			expected: "Friday, 2024-05-17 at 08:30",
+		},
+		{
+			name:     "builtin with layout",
+			content:  `{{date "January 2, 2006"}} {{ time "3:04PM" }}`,
+			expected: "May 17, 2024 8:30AM",
+		},
+		{
+			name:     "variables",
+			content:  "Hello {{name}}!",
+			vars:     map[string]string{"name": "Ada"},
+			expected: "Hello Ada!",
+		},
+		{
+			name:     "missing variable renders empty",
+			content:  "Hello {{name}}!",
+			expected: "Hello !",
+		},
+		{
+			name:     "variable overrides builtin",
+			content:  "{{date}}",
+			vars:     map[string]string{"date": "yesterday"},
+			expected: "yesterday",
+		},
+		{
+			name:     "if true",
+			content:  "{{#if mood}}Mood: {{mood}}{{/if}}",
+			vars:     map[string]string{"mood": "great"},
+			expected: "Mood: great",
+		},
+		{
+			name:     "if false",
+			content:  "{{#if mood}}Mood: {{mood}}{{/if}}",
+			expected: "",
+		},
+		{
+			name:     "if else",
+			content:  "{{#if place}}At {{place}}{{else}}Somewhere{{/if}}",
+			expected: "Somewhere",
+		},
+		{
+			name:     "nested if",
+			content:  "{{#if a}}A{{#if b}}B{{else}}-{{/if}}{{/if}}",
+			vars:     map[string]string{"a": "1"},
+			expected: "A-",
+		},
+		{
+			name:     "daily prompt",
+			content:  "{{prompt}}",
+			expected: dailyPrompt(now),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := renderTemplate(tc.content, tc.vars, now)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, rendered)
+		})
+	}
+}
+
+func TestRenderTemplate_SyntaxErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{name: "unclosed tag", content: "Hello {{name"},
+		{name: "unclosed if", content: "{{#if a}}text"},
+		{name: "stray else", content: "text{{else}}"},
+		{name: "stray end", content: "text{{/if}}"},
+		{name: "if without name", content: "{{#if}}x{{/if}}"},
+		{name: "invalid name", content: "{{first name}}"},
+		{name: "unquoted layout", content: "{{date 2006}}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := renderTemplate(tc.content, nil, time.Now())
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDailyPrompt(t *testing.T) {
+	morning := time.Date(2024, 5, 17, 8, 0, 0, 0, time.UTC)
+	evening := time.Date(2024, 5, 17, 22, 0, 0, 0, time.UTC)
+	nextDay := time.Date(2024, 5, 18, 8, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, dailyPrompt(morning), dailyPrompt(evening))
+	assert.NotEqual(t, dailyPrompt(morning), dailyPrompt(nextDay))
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"maps"
 	"time"
 
 	"github.com/samber/mo"
@@ -14,10 +15,17 @@ type Topic struct {
 	Description       string
 	Color             string
 	DefaultTemplateID mo.Option[string]
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
-	DeletedAt         mo.Option[time.Time]
-	Version           uint64
+
+	// ParentID references the parent topic, absent for top-level topics
+	ParentID mo.Option[string]
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt mo.Option[time.Time]
+	Version   uint64
+
+	// unknown holds details fields written by newer clients
+	unknown unknownFields
 }
 
 // TopicDetails represents the plaintext content structure for topic details
@@ -25,4 +33,39 @@ type TopicDetails struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Color       string `json:"color"`
+	ParentID    string `json:"parent_id,omitempty"`
+
+	unknown unknownFields
+}
+
+func (d TopicDetails) MarshalJSON() ([]byte, error) {
+	type plain TopicDetails
+	return marshalWithUnknown(plain(d), d.unknown)
+}
+
+func (d *TopicDetails) UnmarshalJSON(data []byte) error {
+	type plain TopicDetails
+
+	var p plain
+	unknown, err := unmarshalWithUnknown(data, &p)
+	if err != nil {
+		return err
+	}
+
+	*d = TopicDetails(p)
+	d.unknown = unknown
+
+	return nil
+}
+
+// PutParams returns the parameters that store the topic unchanged with PutTopic
+func (t *Topic) PutParams() PutTopicParams {
+	return PutTopicParams{
+		Title:             t.Title,
+		Description:       t.Description,
+		Color:             t.Color,
+		DefaultTemplateID: t.DefaultTemplateID,
+		ParentID:          t.ParentID,
+		unknown:           maps.Clone(t.unknown),
+	}
 }
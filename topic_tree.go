@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TopicNode is a topic together with its nested topics
+type TopicNode struct {
+	Topic    *Topic
+	Children []*TopicNode
+}
+
+// TopicTree returns the topics of a diary arranged by their parent references.
+// Deleted topics are left out and topics whose parent is missing become top-level
+// topics. Siblings are sorted by title. ErrTopicCycle is returned when parent
+// references form a cycle.
+func (c *Client) TopicTree(ctx context.Context, diaryID string) ([]*TopicNode, error) {
+	topics, err := c.GetTopics(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTopicTree(topics)
+}
+
+// FindTopicByPath finds a topic by the titles of its ancestors and itself separated
+// by slashes, e.g. "Work/Projects/X". Titles are compared exactly.
+func (c *Client) FindTopicByPath(ctx context.Context, diaryID, path string) (*Topic, error) {
+	var titles []string
+	for _, title := range strings.Split(path, "/") {
+		if title = strings.TrimSpace(title); title != "" {
+			titles = append(titles, title)
+		}
+	}
+
+	if len(titles) == 0 {
+		return nil, errors.New("topic path must not be empty")
+	}
+
+	roots, err := c.TopicTree(ctx, diaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *TopicNode
+	level := roots
+	for _, title := range titles {
+		found = nil
+		for _, node := range level {
+			if node.Topic.Title != title {
+				continue
+			}
+
+			if found != nil {
+				return nil, errors.Wrapf(ErrAmbiguousTopicPath, "path %q", path)
+			}
+
+			found = node
+		}
+
+		if found == nil {
+			return nil, errors.Wrapf(ErrTopicNotFound, "path %q", path)
+		}
+
+		level = found.Children
+	}
+
+	return found.Topic, nil
+}
+
+// buildTopicTree arranges topics by their parent references
+func buildTopicTree(topics []*Topic) ([]*TopicNode, error) {
+	nodes := make(map[string]*TopicNode, len(topics))
+	for _, topic := range topics {
+		if topic.DeletedAt.IsPresent() {
+			continue
+		}
+
+		nodes[topic.ID] = &TopicNode{Topic: topic}
+	}
+
+	parents := topicParents(topics)
+	if topicID := findTopicCycle(parents); topicID != "" {
+		return nil, errors.Wrapf(ErrTopicCycle, "topic %s", topicID)
+	}
+
+	var roots []*TopicNode
+	for _, topic := range topics {
+		node, ok := nodes[topic.ID]
+		if !ok {
+			continue
+		}
+
+		if parentID, ok := parents[topic.ID]; ok {
+			nodes[parentID].Children = append(nodes[parentID].Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	sortTopicNodes(roots)
+
+	return roots, nil
+}
+
+// sortTopicNodes sorts siblings by title and then ID, recursively
+func sortTopicNodes(nodes []*TopicNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i].Topic, nodes[j].Topic
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+
+		return a.ID < b.ID
+	})
+
+	for _, node := range nodes {
+		sortTopicNodes(node.Children)
+	}
+}
+
+// topicParents maps the IDs of non-deleted topics to the IDs of their non-deleted parents
+func topicParents(topics []*Topic) map[string]string {
+	live := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		if topic.DeletedAt.IsAbsent() {
+			live[topic.ID] = true
+		}
+	}
+
+	parents := make(map[string]string)
+	for _, topic := range topics {
+		parentID, ok := topic.ParentID.Get()
+		if ok && live[topic.ID] && live[parentID] {
+			parents[topic.ID] = parentID
+		}
+	}
+
+	return parents
+}
+
+// findTopicCycle returns the ID of a topic on a cycle of parent references, or an
+// empty string when there is none
+func findTopicCycle(parents map[string]string) string {
+	ids := make([]string, 0, len(parents))
+	for id := range parents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+
+	state := make(map[string]int, len(parents))
+	for _, id := range ids {
+		var path []string
+		for current, ok := id, true; ok && state[current] != done; current, ok = parents[current] {
+			if state[current] == visiting {
+				return current
+			}
+
+			state[current] = visiting
+			path = append(path, current)
+		}
+
+		for _, visited := range path {
+			state[visited] = done
+		}
+	}
+
+	return ""
+}
+
+// checkTopicParent makes sure parentID exists and setting it as the parent of
+// topicID does not create a cycle
+func (c *Client) checkTopicParent(ctx context.Context, diaryID, topicID, parentID string) error {
+	if parentID == topicID {
+		return errors.Wrap(ErrTopicCycle, "topic cannot be its own parent")
+	}
+
+	topics, err := c.GetTopics(ctx, diaryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get topics")
+	}
+
+	return checkTopicParentIn(topics, topicID, parentID)
+}
+
+// checkTopicParentIn makes sure parentID is one of topics and setting it as the
+// parent of topicID does not create a cycle
+func checkTopicParentIn(topics []*Topic, topicID, parentID string) error {
+	if parentID == topicID {
+		return errors.Wrap(ErrTopicCycle, "topic cannot be its own parent")
+	}
+
+	parentExists := false
+	for _, topic := range topics {
+		if topic.ID == parentID && topic.DeletedAt.IsAbsent() {
+			parentExists = true
+			break
+		}
+	}
+
+	if !parentExists {
+		return errors.Wrapf(ErrTopicNotFound, "parent topic %s", parentID)
+	}
+
+	// Walk up from the new parent; reaching the topic itself would close a cycle
+	parents := topicParents(topics)
+	visited := map[string]bool{}
+	for current, ok := parentID, true; ok && !visited[current]; current, ok = parents[current] {
+		if current == topicID {
+			return errors.Wrapf(ErrTopicCycle, "topic %s is an ancestor of %s", topicID, parentID)
+		}
+
+		visited[current] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTopicTree(t *testing.T) {
+	topics := []*Topic{
+		{ID: "work", Title: "Work"},
+		{ID: "x", Title: "X", ParentID: mo.Some("projects")},
+		{ID: "projects", Title: "Projects", ParentID: mo.Some("work")},
+		{ID: "a", Title: "A", ParentID: mo.Some("projects")},
+		{ID: "orphan", Title: "Orphan", ParentID: mo.Some("missing")},
+		{ID: "deleted", Title: "Deleted", DeletedAt: mo.Some(time.Now())},
+		{ID: "under-deleted", Title: "Under deleted", ParentID: mo.Some("deleted")},
+	}
+
+	roots, err := buildTopicTree(topics)
+	require.NoError(t, err)
+
+	titles := func(nodes []*TopicNode) []string {
+		var result []string
+		for _, node := range nodes {
+			result = append(result, node.Topic.Title)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"Orphan", "Under deleted", "Work"}, titles(roots))
+
+	work := roots[2]
+	require.Len(t, work.Children, 1)
+	assert.Equal(t, []string{"A", "X"}, titles(work.Children[0].Children))
+}
+
+func TestBuildTopicTree_Cycle(t *testing.T) {
+	topics := []*Topic{
+		{ID: "root", Title: "Root"},
+		{ID: "a", Title: "A", ParentID: mo.Some("c")},
+		{ID: "b", Title: "B", ParentID: mo.Some("a")},
+		{ID: "c", Title: "C", ParentID: mo.Some("b")},
+	}
+
+	_, err := buildTopicTree(topics)
+	assert.ErrorIs(t, err, ErrTopicCycle)
+}
+
+func (s *ClientSuite) TestTopic_TopicTree() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: Register and authenticate user
+	var login = fmt.Sprintf("test-topic-tree-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Test Diary"})
+	require.NoError(t, err)
+
+	work, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Work"})
+	require.NoError(t, err)
+
+	projects, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Projects", ParentID: mo.Some(work.ID)})
+	require.NoError(t, err)
+
+	x, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "X", ParentID: mo.Some(projects.ID)})
+	require.NoError(t, err)
+
+	// Act
+	roots, err := s.client.TopicTree(ctx, diary.ID)
+	require.NoError(t, err)
+
+	found, err := s.client.FindTopicByPath(ctx, diary.ID, "Work/Projects/X")
+	require.NoError(t, err)
+
+	_, notFoundErr := s.client.FindTopicByPath(ctx, diary.ID, "Work/Other")
+
+	// Assert
+	require.Len(t, roots, 1)
+	assert.Equal(t, work.ID, roots[0].Topic.ID)
+	require.Len(t, roots[0].Children, 1)
+	assert.Equal(t, projects.ID, roots[0].Children[0].Topic.ID)
+	assert.Equal(t, x.ID, found.ID)
+	assert.Equal(t, mo.Some(projects.ID), found.ParentID)
+	assert.ErrorIs(t, notFoundErr, ErrTopicNotFound)
+}
+
+func (s *ClientSuite) TestTopic_PutTopic_Cycle() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: Register and authenticate user
+	var login = fmt.Sprintf("test-topic-cycle-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Test Diary"})
+	require.NoError(t, err)
+
+	parent, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Parent"})
+	require.NoError(t, err)
+
+	child, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Child", ParentID: mo.Some(parent.ID)})
+	require.NoError(t, err)
+
+	// Act: Make the parent a child of its own child
+	params := parent.PutParams()
+	params.ParentID = mo.Some(child.ID)
+	_, err = s.client.PutTopic(ctx, diary.ID, parent.ID, params)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTopicCycle)
+}
+
+func (s *ClientSuite) TestTopic_DeleteTopic_Children() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange: Register and authenticate user
+	var login = fmt.Sprintf("test-delete-topic-children-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Test Diary"})
+	require.NoError(t, err)
+
+	root, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Root"})
+	require.NoError(t, err)
+
+	middle, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Middle", ParentID: mo.Some(root.ID)})
+	require.NoError(t, err)
+
+	leaf, err := s.client.CreateTopic(ctx, diary.ID, CreateTopicParams{Title: "Leaf", ParentID: mo.Some(middle.ID)})
+	require.NoError(t, err)
+
+	// Act: Delete the middle topic, then the root with its children
+	err = s.client.DeleteTopic(ctx, diary.ID, middle.ID)
+	require.NoError(t, err)
+
+	movedLeaf, err := s.client.GetTopicByID(ctx, diary.ID, leaf.ID)
+	require.NoError(t, err)
+
+	err = s.client.DeleteTopic(ctx, diary.ID, root.ID, DeleteTopicParams{DeleteChildren: true})
+	require.NoError(t, err)
+
+	deletedLeaf, err := s.client.GetTopicByID(ctx, diary.ID, leaf.ID)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, mo.Some(root.ID), movedLeaf.ParentID)
+	assert.True(t, deletedLeaf.DeletedAt.IsPresent())
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TrustStatus describes whether the keys of an account were verified out of band
+type TrustStatus int
+
+const (
+	// TrustUnverified means the account keys were never verified
+	TrustUnverified TrustStatus = iota
+	// TrustVerified means the account keys match the verified ones
+	TrustVerified
+	// TrustKeyChanged means the account keys differ from the verified ones
+	TrustKeyChanged
+)
+
+// TrustRecord is a verified set of account keys
+type TrustRecord struct {
+	AccountID           string    `json:"account_id"`
+	SigningPublicKey    []byte    `json:"signing_public_key"`
+	EncryptionPublicKey []byte    `json:"encryption_public_key"`
+	VerifiedAt          time.Time `json:"verified_at"`
+}
+
+// TrustStore records account keys the user verified out of band and detects key changes.
+// Records are persisted as JSON when the store is backed by a file.
+type TrustStore struct {
+	mu      sync.Mutex
+	path    string
+	records map[string]TrustRecord
+}
+
+// NewTrustStore opens a trust store persisted at path, creating it on first write.
+// An empty path creates a store kept in memory only.
+func NewTrustStore(path string) (*TrustStore, error) {
+	store := TrustStore{
+		path:    path,
+		records: map[string]TrustRecord{},
+	}
+
+	if path == "" {
+		return &store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &store, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read trust store")
+	}
+
+	var records []TrustRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, errors.Wrap(err, "failed to parse trust store")
+	}
+
+	for _, record := range records {
+		store.records[record.AccountID] = record
+	}
+
+	return &store, nil
+}
+
+// MarkVerified records keys the user compared out of band, replacing previously verified keys
+func (s *TrustStore) MarkVerified(accountID string, keys AccountKeys) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[accountID] = TrustRecord{
+		AccountID:           accountID,
+		SigningPublicKey:    keys.SigningPublicKey,
+		EncryptionPublicKey: keys.EncryptionPublicKey,
+		VerifiedAt:          time.Now().UTC(),
+	}
+
+	return s.save()
+}
+
+// Forget removes the verified keys of an account
+func (s *TrustStore) Forget(accountID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, accountID)
+
+	return s.save()
+}
+
+// Get returns the verified keys of an account
+func (s *TrustStore) Get(accountID string) (TrustRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[accountID]
+	return record, ok
+}
+
+// Check compares keys presented for an account with the verified ones.
+// ErrKeyChanged is returned alongside TrustKeyChanged so that callers cannot
+// silently ignore a key change.
+func (s *TrustStore) Check(accountID string, keys AccountKeys) (TrustStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[accountID]
+	if !ok {
+		return TrustUnverified, nil
+	}
+
+	verified := AccountKeys{
+		SigningPublicKey:    record.SigningPublicKey,
+		EncryptionPublicKey: record.EncryptionPublicKey,
+	}
+
+	if !verified.Equal(keys) {
+		return TrustKeyChanged, ErrKeyChanged
+	}
+
+	return TrustVerified, nil
+}
+
+// save persists records atomically
+func (s *TrustStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	records := make([]TrustRecord, 0, len(s.records))
+	for _, record := range s.records {
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].AccountID < records[j].AccountID
+	})
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal trust store")
+	}
+
+	if err := writeFileAtomically(s.path, data); err != nil {
+		return errors.Wrap(err, "failed to save trust store")
+	}
+
+	return nil
+}
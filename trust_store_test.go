@@ -0,0 +1,46 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trust.json")
+
+	alice, err := NewCredentials("alice seed phrase")
+	require.NoError(t, err)
+
+	rotated, err := NewCredentials("alice new seed phrase")
+	require.NoError(t, err)
+
+	store, err := NewTrustStore(path)
+	require.NoError(t, err)
+
+	status, err := store.Check("alice", alice.AccountKeys())
+	require.NoError(t, err)
+	assert.Equal(t, TrustUnverified, status)
+
+	require.NoError(t, store.MarkVerified("alice", alice.AccountKeys()))
+
+	status, err = store.Check("alice", alice.AccountKeys())
+	require.NoError(t, err)
+	assert.Equal(t, TrustVerified, status)
+
+	// Records survive reopening the store
+	reopened, err := NewTrustStore(path)
+	require.NoError(t, err)
+
+	status, err = reopened.Check("alice", rotated.AccountKeys())
+	assert.ErrorIs(t, err, ErrKeyChanged)
+	assert.Equal(t, TrustKeyChanged, status)
+
+	require.NoError(t, reopened.Forget("alice"))
+
+	status, err = reopened.Check("alice", rotated.AccountKeys())
+	require.NoError(t, err)
+	assert.Equal(t, TrustUnverified, status)
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"strings"
+)
+
+// unknownFields holds JSON object keys of encrypted details that this client does
+// not know, e.g. written by a newer client. They are kept verbatim and written back
+// on update, so older clients do not erase data they cannot interpret.
+type unknownFields map[string]json.RawMessage
+
+// marshalWithUnknown marshals v, a struct, and adds the unknown fields to the object
+func marshalWithUnknown(v any, unknown unknownFields) ([]byte, error) {
+	known, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(unknown) == 0 {
+		return known, nil
+	}
+
+	// Known fields take precedence over preserved ones
+	fields := maps.Clone(unknown)
+	if err := json.Unmarshal(known, &fields); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// unmarshalWithUnknown unmarshals data into v, a pointer to a struct, and returns
+// the object keys that do not match any of its fields
+func unmarshalWithUnknown(data []byte, v any) (unknownFields, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	names := jsonFieldNames(reflect.TypeOf(v).Elem())
+
+	unknown := make(unknownFields)
+	for key, value := range fields {
+		// encoding/json matches keys to fields case-insensitively
+		isKnown := false
+		for _, name := range names {
+			if strings.EqualFold(key, name) {
+				isKnown = true
+				break
+			}
+		}
+
+		if !isKnown {
+			unknown[key] = value
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil, nil
+	}
+
+	return unknown, nil
+}
+
+// jsonFieldNames returns the JSON names of the exported fields of a struct type
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
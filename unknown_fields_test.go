@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// encryptDetailsForTest encrypts raw details JSON the way Put* does and returns
+// the entity encryption metadata and encrypted details
+func encryptDetailsForTest(t *testing.T, details string, diaryKey []byte) (openapi.DiaryEncryption, openapi.EncryptedData) {
+	t.Helper()
+
+	entityKey, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	keyNonce, encryptedEntityKey, err := encryptWithSymmetricKey(entityKey, diaryKey)
+	require.NoError(t, err)
+
+	detailsNonce, encryptedDetails, err := encryptWithSymmetricKey([]byte(details), entityKey)
+	require.NoError(t, err)
+
+	encryption := openapi.DiaryEncryption{
+		DiaryKeyId:        "key-1",
+		EncryptedKeyNonce: keyNonce,
+		EncryptedKeyData:  encryptedEntityKey,
+	}
+
+	return encryption, openapi.EncryptedData{Nonce: detailsNonce, Data: encryptedDetails}
+}
+
+// assertJSONField asserts that the JSON object data has key with the given value
+func assertJSONField(t *testing.T, data []byte, key, expected string) {
+	t.Helper()
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &fields))
+	require.Contains(t, fields, key)
+	assert.JSONEq(t, expected, string(fields[key]))
+}
+
+func TestUnknownFields_Entry(t *testing.T) {
+	diaryKey, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	// Written by a newer client
+	encryption, details := encryptDetailsForTest(t, `{
+		"content": "Hello",
+		"bookmarked": true,
+		"location_trail": [[1, 2], [3, 4]],
+		"reactions": {"heart": 3}
+	}`, diaryKey)
+
+	c := NewClient()
+	entry, err := c.decryptEntry(&openapi.Entry{Id: "entry-1", Encryption: encryption, Details: details}, diaryKey)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", entry.Content)
+
+	// An older client edits a known field
+	params := entry.PutParams()
+	params.Content = "Hello again"
+
+	data, err := json.Marshal(params.GetEntryDetails())
+	require.NoError(t, err)
+
+	assertJSONField(t, data, "content", `"Hello again"`)
+	assertJSONField(t, data, "bookmarked", `true`)
+	assertJSONField(t, data, "location_trail", `[[1, 2], [3, 4]]`)
+	assertJSONField(t, data, "reactions", `{"heart": 3}`)
+}
+
+func TestUnknownFields_Topic(t *testing.T) {
+	diaryKey, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	encryption, details := encryptDetailsForTest(t, `{"title": "Work", "icon": "briefcase"}`, diaryKey)
+
+	c := NewClient()
+	topic, err := c.decryptTopic(&openapi.Topic{Id: "topic-1", Encryption: encryption, Details: details}, diaryKey)
+	require.NoError(t, err)
+
+	params := topic.PutParams()
+	params.Color = "#ff0000"
+
+	data, err := json.Marshal(params.GetTopicDetails())
+	require.NoError(t, err)
+
+	assertJSONField(t, data, "title", `"Work"`)
+	assertJSONField(t, data, "color", `"#ff0000"`)
+	assertJSONField(t, data, "icon", `"briefcase"`)
+}
+
+func TestUnknownFields_Template(t *testing.T) {
+	diaryKey, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	encryption, details := encryptDetailsForTest(t, `{"content": "Today I...", "locale": "en-GB"}`, diaryKey)
+
+	c := NewClient()
+	template, err := c.decryptTemplate(&openapi.Template{Id: "template-1", Encryption: encryption, Details: details}, diaryKey)
+	require.NoError(t, err)
+
+	params := template.PutParams()
+	params.Content = "Today I learned..."
+
+	data, err := json.Marshal(params.GetTemplateDetails())
+	require.NoError(t, err)
+
+	assertJSONField(t, data, "content", `"Today I learned..."`)
+	assertJSONField(t, data, "locale", `"en-GB"`)
+}
+
+func TestUnknownFields_Diary(t *testing.T) {
+	var details DiaryDetails
+	require.NoError(t, json.Unmarshal([]byte(`{"title": "Travel", "cover": {"color": "blue"}}`), &details))
+
+	diary := Diary{Title: details.Title, Description: details.Description, unknown: details.unknown}
+
+	params := diary.PutParams()
+	params.Description = "Trips abroad"
+
+	data, err := json.Marshal(params.GetDiaryDetails())
+	require.NoError(t, err)
+
+	assertJSONField(t, data, "title", `"Travel"`)
+	assertJSONField(t, data, "description", `"Trips abroad"`)
+	assertJSONField(t, data, "cover", `{"color": "blue"}`)
+}
+
+func TestUnknownFields_KnownKeysAreNotPreserved(t *testing.T) {
+	// encoding/json matches keys case-insensitively, so "Content" is a known field
+	var details TemplateDetails
+	require.NoError(t, json.Unmarshal([]byte(`{"Content": "old"}`), &details))
+
+	assert.Equal(t, "old", details.Content)
+	assert.Empty(t, details.unknown)
+
+	details.Content = "new"
+	data, err := json.Marshal(details)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"content": "new"}`, string(data))
+}
+
+func TestUnknownFields_NewEntitiesHaveNone(t *testing.T) {
+	data, err := json.Marshal(PutEntryParams{Content: "New"}.GetEntryDetails())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"content": "New", "archived": false, "bookmarked": false, "preview_hidden": false}`, string(data))
+}
+
+func TestUnknownFields_PutKeepsStoredFields(t *testing.T) {
+	credentials, err := NewCredentials("unknown fields seed phrase")
+	require.NoError(t, err)
+
+	diaryKey, err := generateSymmetricKey()
+	require.NoError(t, err)
+
+	sealedDiaryKey, err := encryptWithPublicKey(diaryKey, credentials.EncryptionPublicKey)
+	require.NoError(t, err)
+
+	encryption, details := encryptDetailsForTest(t, `{"title": "Work", "icon": "briefcase"}`, diaryKey)
+
+	var put openapi.PutTopicRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/v1/diaries/diary-1/keys":
+			_ = json.NewEncoder(w).Encode(openapi.GetDiaryKeysResponse{Keys: []openapi.DiaryEncryptionKey{
+				{Id: "key-1", Status: openapi.Active, Value: sealedDiaryKey},
+			}})
+		case r.URL.Path == "/v1/diaries/diary-1/topics/topic-1" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(openapi.GetTopicResponse{Topic: openapi.Topic{Id: "topic-1", Encryption: encryption, Details: details}})
+		case r.URL.Path == "/v1/diaries/diary-1/topics/topic-1" && r.Method == http.MethodPut:
+			_ = json.NewDecoder(r.Body).Decode(&put)
+			_ = json.NewEncoder(w).Encode(openapi.PutTopicResponse{Topic: openapi.Topic{Id: "topic-1", Encryption: put.Encryption, Details: put.Details}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	client.authToken = "token"
+	client.credentials = credentials
+
+	// Built from scratch, e.g. by an import, so it carries no unknown fields
+	topic, err := client.PutTopic(context.Background(), "diary-1", "topic-1", PutTopicParams{Title: "Work", Color: "#ff0000"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "#ff0000", topic.Color)
+
+	entityKey, err := decryptWithSymmetricKey(put.Encryption.EncryptedKeyNonce, put.Encryption.EncryptedKeyData, diaryKey)
+	require.NoError(t, err)
+
+	data, err := decryptWithSymmetricKey(put.Details.Nonce, put.Details.Data, entityKey)
+	require.NoError(t, err)
+
+	assertJSONField(t, data, "color", `"#ff0000"`)
+	assertJSONField(t, data, "icon", `"briefcase"`)
+}
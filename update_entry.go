@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// UpdateEntry writes params as the new version of an entry that was read as base.
+// When the entry changed on the server since base was read, the conflict is passed
+// to resolve, e.g. ThreeWayMerge, and the resolution is written instead. A conflict
+// left to the caller is returned as an *EntryConflictError. The local change counts
+// as made at the version of base, so LastWriterWins keeps a remote change made since.
+func (c *Client) UpdateEntry(
+	ctx context.Context,
+	diaryID string,
+	base *Entry,
+	params PutEntryParams,
+	resolve EntryResolver,
+) (*Entry, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	if base == nil {
+		return nil, errors.New("base entry must not be nil")
+	}
+
+	remote, err := c.GetEntryByID(ctx, diaryID, base.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get entry")
+	}
+
+	if remote.Version == base.Version {
+		entry, err := c.PutEntry(ctx, diaryID, base.ID, params)
+		if !errors.Is(err, ErrVersionConflict) {
+			return entry, err
+		}
+
+		// Changed between reading and writing
+		remote, err = c.GetEntryByID(ctx, diaryID, base.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get entry")
+		}
+	}
+
+	conflict := EntryConflict{
+		DiaryID:      diaryID,
+		EntryID:      base.ID,
+		Base:         base,
+		Local:        params,
+		LocalVersion: base.Version,
+		Remote:       remote,
+	}
+
+	return c.applyEntryResolution(ctx, conflict, resolve)
+}
+
+// applyEntryResolution resolves a conflict and writes the outcome, returning the
+// resulting version of the conflicting entry
+func (c *Client) applyEntryResolution(ctx context.Context, conflict EntryConflict, resolve EntryResolver) (*Entry, error) {
+	resolution, err := resolve(conflict)
+	if err != nil {
+		return nil, err
+	}
+
+	if conflictCopy, ok := resolution.Copy.Get(); ok {
+		if _, err := c.PutEntry(ctx, conflict.DiaryID, uuid.NewString(), conflictCopy); err != nil {
+			return nil, errors.Wrap(err, "failed to create conflict copy")
+		}
+	}
+
+	params, ok := resolution.Params.Get()
+	if !ok {
+		return conflict.Remote, nil
+	}
+
+	return c.PutEntry(ctx, conflict.DiaryID, conflict.EntryID, params)
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestEntry_UpdateEntry_ThreeWayMerge() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-update-entry-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	base, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "first\nsecond\n"})
+	require.NoError(t, err)
+
+	// Another device appends a line
+	remoteParams := base.PutParams()
+	remoteParams.Content = "first\nsecond\nthird\n"
+	remoteParams.Archived = true
+	_, err = s.client.PutEntry(ctx, diary.ID, base.ID, remoteParams)
+	require.NoError(t, err)
+
+	// Act: Edit the first line of the stale copy
+	localParams := base.PutParams()
+	localParams.Content = "First\nsecond\n"
+	localParams.Bookmarked = true
+	merged, err := s.client.UpdateEntry(ctx, diary.ID, base, localParams, ThreeWayMerge)
+	require.NoError(t, err)
+
+	_, conflictErr := s.client.UpdateEntry(ctx, diary.ID, base, PutEntryParams{Content: "other\nsecond\n"}, ThreeWayMerge)
+
+	// Assert
+	assert.Equal(t, "First\nsecond\nthird\n", merged.Content)
+	assert.True(t, merged.Archived)
+	assert.True(t, merged.Bookmarked)
+	assert.ErrorIs(t, conflictErr, ErrVersionConflict)
+}
+
+func (s *ClientSuite) TestEntry_UpdateEntry_LastWriterWinsKeepsLaterRemote() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-update-entry-lww-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	base, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{Content: "Original"})
+	require.NoError(t, err)
+
+	remoteParams := base.PutParams()
+	remoteParams.Content = "Edited on another device"
+	_, err = s.client.PutEntry(ctx, diary.ID, base.ID, remoteParams)
+	require.NoError(t, err)
+
+	// Act: Write a change made to the copy read before the remote edit
+	localParams := base.PutParams()
+	localParams.Content = "Edited stale copy"
+	entry, err := s.client.UpdateEntry(ctx, diary.ID, base, localParams, LastWriterWins)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Edited on another device", entry.Content)
+}
+
+func TestUpdateEntry_RequiresBase(t *testing.T) {
+	credentials, err := NewCredentials("update entry seed phrase")
+	require.NoError(t, err)
+
+	client := NewClient()
+	client.credentials = credentials
+
+	_, err = client.UpdateEntry(context.Background(), "diary-1", nil, PutEntryParams{Content: "New"}, LastWriterWins)
+	assert.EqualError(t, err, "base entry must not be nil")
+}
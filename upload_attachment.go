@@ -0,0 +1,398 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/thingsdiary/client-go/openapi"
+)
+
+// UploadAttachmentParams describes an attachment to upload
+type UploadAttachmentParams struct {
+	Name     string
+	MimeType string
+
+	// ChunkSize is the plaintext chunk size, DefaultAttachmentChunkSize when zero
+	ChunkSize int
+}
+
+// UploadAttachment encrypts r in chunks under a fresh attachment key and uploads it.
+// Store the returned reference in EntryDetails via PutEntryParams.Attachments.
+//
+// When r is an io.ReadSeeker its content is hashed first, so an identical attachment
+// already stored in the diary is reused instead of being uploaded again.
+// If the upload is interrupted, call ResumeAttachmentUpload with the attachment ID
+// and the same content.
+func (c *Client) UploadAttachment(ctx context.Context, diaryID string, r io.Reader, params UploadAttachmentParams) (*AttachmentRef, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	chunkSize := params.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultAttachmentChunkSize
+	}
+
+	// Get encryption keys
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get diary keys")
+	}
+
+	diaryKey := keyring.active()
+
+	var contentID []byte
+	var contentHash []byte
+	var size int64
+	if seeker, ok := r.(io.ReadSeeker); ok {
+		contentHash, size, err = hashSeekableContent(seeker)
+		if err != nil {
+			return nil, err
+		}
+
+		contentID = attachmentContentID(contentHash, diaryKey)
+	}
+
+	// Generate attachment key and nonce prefix
+	attachmentKey, err := generateSymmetricKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate attachment key")
+	}
+
+	noncePrefix := make([]byte, openapi.AttachmentNoncePrefixSize)
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce prefix")
+	}
+
+	// Encrypt attachment key with diary key
+	keyNonce, encryptedAttachmentKey, err := encryptWithSymmetricKey(attachmentKey, diaryKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt attachment key")
+	}
+
+	request := openapi.CreateAttachmentRequest{
+		ChunkSize:   chunkSize,
+		ContentId:   contentID,
+		NoncePrefix: noncePrefix,
+		Encryption: openapi.DiaryEncryption{
+			DiaryKeyId:        keyring.activeKeyID,
+			EncryptedKeyNonce: keyNonce,
+			EncryptedKeyData:  encryptedAttachmentKey,
+		},
+	}
+
+	attachment, err := c.createAttachment(ctx, diaryID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := AttachmentRef{
+		ID:          attachment.Id,
+		Name:        params.Name,
+		MimeType:    params.MimeType,
+		Size:        size,
+		ContentHash: contentHash,
+	}
+
+	// The server returned an existing attachment with the same content
+	if attachment.Completed {
+		return &ref, nil
+	}
+
+	size, contentHash, err = c.uploadAttachmentContent(ctx, diaryID, attachment, attachmentKey, diaryKey, r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to upload attachment %s", attachment.Id)
+	}
+
+	ref.Size = size
+	ref.ContentHash = contentHash
+
+	return &ref, nil
+}
+
+// ResumeAttachmentUpload continues an interrupted upload. r must yield the same
+// content from the beginning; chunks already stored on the server are skipped.
+func (c *Client) ResumeAttachmentUpload(ctx context.Context, diaryID, attachmentID string, r io.Reader, params UploadAttachmentParams) (*AttachmentRef, error) {
+	if c.credentials == nil {
+		return nil, ErrUnauthorized
+	}
+
+	// Get encryption keys
+	keyring, err := c.getDiaryKeyring(ctx, diaryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get diary keys")
+	}
+
+	attachment, err := c.getAttachment(ctx, diaryID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentKey, err := decryptAttachmentKey(attachment, keyring)
+	if err != nil {
+		return nil, err
+	}
+
+	size, contentHash, err := c.uploadAttachmentContent(ctx, diaryID, attachment, attachmentKey, keyring.active(), r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to upload attachment %s", attachment.Id)
+	}
+
+	ref := AttachmentRef{
+		ID:          attachment.Id,
+		Name:        params.Name,
+		MimeType:    params.MimeType,
+		Size:        size,
+		ContentHash: contentHash,
+	}
+
+	return &ref, nil
+}
+
+// uploadAttachmentContent encrypts and uploads every chunk missing on the server,
+// then completes the attachment. It returns the plaintext size and SHA-256.
+func (c *Client) uploadAttachmentContent(
+	ctx context.Context,
+	diaryID string,
+	attachment *openapi.Attachment,
+	attachmentKey []byte,
+	diaryKey []byte,
+	r io.Reader,
+) (int64, []byte, error) {
+	aead, err := newGCM(attachmentKey)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	uploaded := make(map[int]bool, len(attachment.UploadedChunks))
+	for _, index := range attachment.UploadedChunks {
+		uploaded[index] = true
+	}
+
+	hash := sha256.New()
+	chunks := newChunkReader(r, attachment.ChunkSize)
+
+	var size int64
+	var index int
+	for final := false; !final; index++ {
+		var chunk []byte
+		chunk, final, err = chunks.next()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		hash.Write(chunk)
+		size += int64(len(chunk))
+
+		// Already stored chunks still have to be read to hash the content
+		if attachment.Completed || uploaded[index] {
+			continue
+		}
+
+		sealed, err := sealAttachmentChunk(aead, attachment, index, final, chunk)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if err := c.uploadAttachmentChunk(ctx, diaryID, attachment.Id, index, sealed); err != nil {
+			return 0, nil, errors.Wrapf(err, "failed to upload chunk %d", index)
+		}
+	}
+
+	contentHash := hash.Sum(nil)
+
+	if !attachment.Completed {
+		request := openapi.CompleteAttachmentRequest{
+			ChunkCount: index,
+			ContentId:  attachmentContentID(contentHash, diaryKey),
+		}
+
+		if err := c.completeAttachment(ctx, diaryID, attachment.Id, request); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	return size, contentHash, nil
+}
+
+// hashSeekableContent hashes the whole content and rewinds it
+func hashSeekableContent(r io.ReadSeeker) ([]byte, int64, error) {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to seek attachment")
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, r)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to read attachment")
+	}
+
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to seek attachment")
+	}
+
+	return hash.Sum(nil), size, nil
+}
+
+// decryptAttachmentKey unwraps the attachment key with the diary key it references
+func decryptAttachmentKey(attachment *openapi.Attachment, keyring *diaryKeyring) ([]byte, error) {
+	diaryKey, err := keyring.get(attachment.Encryption.DiaryKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentKey, err := decryptWithSymmetricKey(
+		attachment.Encryption.EncryptedKeyNonce,
+		attachment.Encryption.EncryptedKeyData,
+		diaryKey,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt attachment key")
+	}
+
+	return attachmentKey, nil
+}
+
+func (c *Client) createAttachment(ctx context.Context, diaryID string, request openapi.CreateAttachmentRequest) (*openapi.Attachment, error) {
+	// Validate request before sending
+	if err := request.Validate(); err != nil {
+		return nil, errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/diaries/%s/attachments", c.baseURL, diaryID)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodPost, url, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+
+	signature := signBytes(requestJSON, c.credentials.SigningPrivateKey)
+	req.Header.Set("X-Signature", base64.StdEncoding.EncodeToString(signature))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDiaryNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, ErrForbidden
+	}
+
+	// 200 means an attachment with the same content ID already exists
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	var apiResponse openapi.CreateAttachmentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response")
+	}
+
+	if err := validateAttachment(&apiResponse.Attachment); err != nil {
+		return nil, err
+	}
+
+	return &apiResponse.Attachment, nil
+}
+
+func (c *Client) uploadAttachmentChunk(ctx context.Context, diaryID, attachmentID string, index int, sealed []byte) error {
+	url := fmt.Sprintf("%s/v1/diaries/%s/attachments/%s/chunks/%d", c.baseURL, diaryID, attachmentID, index)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodPut, url, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(sealed))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(sealed)), nil
+	}
+	req.ContentLength = int64(len(sealed))
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	signature := signBytes(sealed, c.credentials.SigningPrivateKey)
+	req.Header.Set("X-Signature", base64.StdEncoding.EncodeToString(signature))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrAttachmentNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return ErrForbidden
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (c *Client) completeAttachment(ctx context.Context, diaryID, attachmentID string, request openapi.CompleteAttachmentRequest) error {
+	// Validate request before sending
+	if err := request.Validate(); err != nil {
+		return errors.Wrap(err, "request validation failed")
+	}
+
+	url := fmt.Sprintf("%s/v1/diaries/%s/attachments/%s/complete", c.baseURL, diaryID, attachmentID)
+	req, err := c.newAuthenticatedRequest(ctx, http.MethodPost, url, request)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request")
+	}
+
+	signature := signBytes(requestJSON, c.credentials.SigningPrivateKey)
+	req.Header.Set("X-Signature", base64.StdEncoding.EncodeToString(signature))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrAttachmentNotFound
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return ErrForbidden
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return errors.New("attachment has missing chunks")
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ClientSuite) TestAttachment_UploadDownload() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-attachment-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	content := make([]byte, 3*64*1024+123)
+	_, err = rand.Read(content)
+	require.NoError(t, err)
+
+	// Act: Upload from a plain reader, so the content is not hashed up front
+	ref, err := s.client.UploadAttachment(ctx, diary.ID, io.MultiReader(bytes.NewReader(content)), UploadAttachmentParams{
+		Name:      "photo.jpg",
+		MimeType:  "image/jpeg",
+		ChunkSize: 64 * 1024,
+	})
+	require.NoError(t, err)
+
+	entry, err := s.client.CreateEntry(ctx, diary.ID, CreateEntryParams{
+		Content:     "Beach day",
+		Attachments: []AttachmentRef{*ref},
+	})
+	require.NoError(t, err)
+
+	// Assert: Reference round trips through the encrypted entry details
+	gotEntry, err := s.client.GetEntryByID(ctx, diary.ID, entry.ID)
+	require.NoError(t, err)
+	require.Len(t, gotEntry.Attachments, 1)
+	assert.Equal(t, "photo.jpg", gotEntry.Attachments[0].Name)
+	assert.Equal(t, int64(len(content)), gotEntry.Attachments[0].Size)
+
+	var downloaded bytes.Buffer
+	err = s.client.DownloadAttachment(ctx, diary.ID, gotEntry.Attachments[0], &downloaded)
+	require.NoError(t, err)
+	assert.Equal(t, content, downloaded.Bytes())
+}
+
+func (s *ClientSuite) TestAttachment_Deduplicated() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-attachment-dedup-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	content := []byte("voice memo")
+
+	// Act
+	first, err := s.client.UploadAttachment(ctx, diary.ID, bytes.NewReader(content), UploadAttachmentParams{Name: "memo.m4a"})
+	require.NoError(t, err)
+
+	second, err := s.client.UploadAttachment(ctx, diary.ID, bytes.NewReader(content), UploadAttachmentParams{Name: "copy.m4a"})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, first.ContentHash, second.ContentHash)
+	assert.Equal(t, "copy.m4a", second.Name)
+}
+
+func (s *ClientSuite) TestAttachment_DownloadCorruptedReference() {
+	t := s.T()
+	ctx := context.Background()
+
+	// Arrange
+	var login = fmt.Sprintf("test-attachment-corrupted-%d@thingsdiary.io", time.Now().UnixMilli())
+	err := s.client.Register(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	err = s.client.Authenticate(ctx, login, "password-123", s.seedPhrase)
+	require.NoError(t, err)
+
+	diary, err := s.client.CreateDiary(ctx, CreateDiaryParams{Title: "Personal Diary"})
+	require.NoError(t, err)
+
+	ref, err := s.client.UploadAttachment(ctx, diary.ID, bytes.NewReader([]byte("original")), UploadAttachmentParams{Name: "note.txt"})
+	require.NoError(t, err)
+
+	ref.ContentHash = bytes.Repeat([]byte{0}, 32)
+
+	// Act
+	err = s.client.DownloadAttachment(ctx, diary.ID, *ref, io.Discard)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrAttachmentCorrupted)
+}
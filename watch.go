@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// Watch polling defaults
+const (
+	DefaultWatchInterval    = 10 * time.Second
+	DefaultWatchMaxInterval = 5 * time.Minute
+)
+
+// WatchParams configures Watch
+type WatchParams struct {
+	// SinceVersion is the version to report changes after; zero reports everything first
+	SinceVersion uint64
+
+	// Interval is the delay between polls, DefaultWatchInterval when zero
+	Interval time.Duration
+
+	// MaxInterval caps the delay after failed or empty polls, DefaultWatchMaxInterval when zero
+	MaxInterval time.Duration
+}
+
+// WatchEvent is a change, or a failed poll when Err is set
+type WatchEvent struct {
+	Change Change
+	Err    error
+}
+
+// Watch polls Changes of a diary and emits every change on the returned channel.
+// The delay between polls doubles after polls that fail or find nothing, up to
+// MaxInterval, and returns to Interval once changes arrive. Failed polls are
+// emitted as events with Err set and retried. The channel is closed when ctx is done.
+func (c *Client) Watch(ctx context.Context, diaryID string, params WatchParams) <-chan WatchEvent {
+	return watchChanges(ctx, params, func(ctx context.Context, sinceVersion uint64) (*ChangeSet, error) {
+		return c.Changes(ctx, diaryID, sinceVersion)
+	})
+}
+
+// watchChanges emits the changes returned by poll until ctx is done
+func watchChanges(
+	ctx context.Context,
+	params WatchParams,
+	poll func(ctx context.Context, sinceVersion uint64) (*ChangeSet, error),
+) <-chan WatchEvent {
+	interval := params.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	maxInterval := params.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultWatchMaxInterval
+	}
+	maxInterval = max(maxInterval, interval)
+
+	events := make(chan WatchEvent)
+
+	go func() {
+		defer close(events)
+
+		emit := func(event WatchEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		since := params.SinceVersion
+		delay := interval
+		for {
+			changeSet, err := poll(ctx, since)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				if !emit(WatchEvent{Err: err}) {
+					return
+				}
+				delay = min(delay*2, maxInterval)
+			case len(changeSet.Changes) == 0:
+				delay = min(delay*2, maxInterval)
+			default:
+				for _, change := range changeSet.Changes {
+					if !emit(WatchEvent{Change: change}) {
+						return
+					}
+				}
+				since = changeSet.Version
+				delay = interval
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	return events
+}
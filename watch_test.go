@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var polledSince []uint64
+	polls := []func() (*ChangeSet, error){
+		func() (*ChangeSet, error) {
+			return &ChangeSet{Changes: []Change{{Version: 5, Entry: &Entry{ID: "a"}}}, Version: 5}, nil
+		},
+		func() (*ChangeSet, error) { return nil, errors.New("offline") },
+		func() (*ChangeSet, error) { return &ChangeSet{Version: 5}, nil },
+		func() (*ChangeSet, error) {
+			return &ChangeSet{Changes: []Change{{Kind: ChangeDeleted, Version: 7, Entry: &Entry{ID: "a"}}}, Version: 7}, nil
+		},
+	}
+
+	events := watchChanges(ctx, WatchParams{SinceVersion: 1, Interval: time.Millisecond, MaxInterval: 4 * time.Millisecond},
+		func(ctx context.Context, sinceVersion uint64) (*ChangeSet, error) {
+			polledSince = append(polledSince, sinceVersion)
+			if len(polledSince) > len(polls) {
+				return &ChangeSet{Version: sinceVersion}, nil
+			}
+
+			return polls[len(polledSince)-1]()
+		})
+
+	first := <-events
+	require.NoError(t, first.Err)
+	assert.Equal(t, uint64(5), first.Change.Version)
+
+	failed := <-events
+	assert.EqualError(t, failed.Err, "offline")
+
+	deleted := <-events
+	require.NoError(t, deleted.Err)
+	assert.Equal(t, ChangeDeleted, deleted.Change.Kind)
+
+	cancel()
+	for range events {
+	}
+
+	assert.Equal(t, []uint64{1, 5, 5, 5}, polledSince[:4])
+}